@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strconv"
 )
 
@@ -16,6 +17,7 @@ type File struct {
 	ContentType string      `json:"contentType,omitempty"`
 	URL         string      `json:"url,omitempty"`
 	UploadURL   string      `json:"upload,omitempty"`
+	UploadFrom  *Link       `json:"uploadFrom,omitempty"`
 	Detail      *FileDetail `json:"details,omitempty"`
 }
 
@@ -31,99 +33,214 @@ type FileImage struct {
 	Height int `json:"height,omitempty"`
 }
 
-// FileFields model
+// FileFields model holds the asset fields keyed by locale code
 type FileFields struct {
-	Title       string `json:"title,omitempty"`
-	Description string `json:"description,omitempty"`
-	File        *File  `json:"file,omitempty"`
+	Title       map[string]string `json:"title,omitempty"`
+	Description map[string]string `json:"description,omitempty"`
+	File        map[string]*File  `json:"file,omitempty"`
 }
 
 // Asset model
 type Asset struct {
-	locale string
-	Sys    *Sys        `json:"sys"`
-	Fields *FileFields `json:"fields"`
+	Sys      *Sys        `json:"sys"`
+	Metadata *Metadata   `json:"metadata,omitempty"`
+	Fields   *FileFields `json:"fields"`
 }
 
 // MarshalJSON for custom json marshaling
 func (asset *Asset) MarshalJSON() ([]byte, error) {
-	payload := map[string]interface{}{
-		"sys": "",
-		"fields": map[string]interface{}{
-			"title":       map[string]string{},
-			"description": map[string]string{},
-			"file":        map[string]interface{}{},
-		},
+	fields := asset.Fields
+	if fields == nil {
+		fields = &FileFields{}
 	}
 
-	payload["sys"] = asset.Sys
-	fields := payload["fields"].(map[string]interface{})
-
-	// title
-	title := fields["title"].(map[string]string)
-	title[asset.locale] = asset.Fields.Title
-
-	// description
-	description := fields["description"].(map[string]string)
-	description[asset.locale] = asset.Fields.Description
-
-	// file
-	file := fields["file"].(map[string]interface{})
-	file[asset.locale] = asset.Fields.File
-
-	return json.Marshal(payload)
+	return json.Marshal(&struct {
+		Sys      *Sys        `json:"sys"`
+		Metadata *Metadata   `json:"metadata,omitempty"`
+		Fields   *FileFields `json:"fields"`
+	}{
+		Sys:      asset.Sys,
+		Metadata: asset.Metadata,
+		Fields:   fields,
+	})
 }
 
-// UnmarshalJSON for custom json unmarshaling
+// UnmarshalJSON for custom json unmarshaling.
+// Fields are either keyed by locale, as returned by the CMA or with `locale=*`,
+// or hold the values of a single locale, which are then keyed by `sys.locale`.
 func (asset *Asset) UnmarshalJSON(data []byte) error {
-	type Alias *Asset
+	var payload struct {
+		Sys      *Sys                       `json:"sys"`
+		Metadata *Metadata                  `json:"metadata"`
+		Fields   map[string]json.RawMessage `json:"fields"`
+	}
 
-	var payload map[string]interface{}
 	if err := json.Unmarshal(data, &payload); err != nil {
 		return err
 	}
 
-	fileName := payload["fields"].(map[string]interface{})["file"].(map[string]interface{})["fileName"]
-	localized := true
-
-	if fileName == nil {
-		localized = false
+	locale := ""
+	if payload.Sys != nil {
+		locale = payload.Sys.Locale
 	}
 
-	if localized == false {
-		asset.Sys = &Sys{}
-		b, _ := json.Marshal(payload["sys"])
-		if err := json.Unmarshal(b, asset.Sys); err != nil {
-			return err
-		}
+	asset.Sys = payload.Sys
+	asset.Metadata = payload.Metadata
+	asset.Fields = &FileFields{}
 
-		title := payload["fields"].(map[string]interface{})["title"]
-		if title != nil {
-			title = title.(map[string]interface{})[asset.locale]
+	var err error
+	if raw, ok := payload.Fields["title"]; ok {
+		if asset.Fields.Title, err = unmarshalLocalizedString(raw, locale); err != nil {
+			return fmt.Errorf("asset title: %s", err)
 		}
+	}
 
-		description := payload["fields"].(map[string]interface{})["description"]
-		if description != nil {
-			description = description.(map[string]interface{})[asset.locale]
+	if raw, ok := payload.Fields["description"]; ok {
+		if asset.Fields.Description, err = unmarshalLocalizedString(raw, locale); err != nil {
+			return fmt.Errorf("asset description: %s", err)
 		}
+	}
 
-		asset.Fields = &FileFields{
-			Title:       title.(string),
-			Description: description.(string),
-			File:        &File{},
+	if raw, ok := payload.Fields["file"]; ok {
+		if asset.Fields.File, err = unmarshalLocalizedFile(raw, locale); err != nil {
+			return fmt.Errorf("asset file: %s", err)
 		}
+	}
 
-		file := payload["fields"].(map[string]interface{})["file"].(map[string]interface{})[asset.locale]
-		if err := json.Unmarshal([]byte(file.(string)), asset.Fields.File); err != nil {
-			return err
+	return nil
+}
+
+func unmarshalLocalizedString(data json.RawMessage, locale string) (map[string]string, error) {
+	var value string
+	if err := json.Unmarshal(data, &value); err == nil {
+		return map[string]string{locale: value}, nil
+	}
+
+	values := map[string]string{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+func unmarshalLocalizedFile(data json.RawMessage, locale string) (map[string]*File, error) {
+	var payload map[string]json.RawMessage
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, err
+	}
+
+	// a single file is recognized by its own properties
+	for _, key := range []string{"fileName", "contentType", "url", "upload", "uploadFrom", "details"} {
+		if _, ok := payload[key]; ok {
+			var file File
+			if err := json.Unmarshal(data, &file); err != nil {
+				return nil, err
+			}
+
+			return map[string]*File{locale: &file}, nil
 		}
-	} else {
-		if err := json.Unmarshal(data, Alias(asset)); err != nil {
-			return err
+	}
+
+	files := map[string]*File{}
+	if err := json.Unmarshal(data, &files); err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// Locales returns the codes of the locales holding a file, title or description
+func (asset *Asset) Locales() []string {
+	locales := []string{}
+	if asset.Fields == nil {
+		return locales
+	}
+
+	seen := map[string]bool{}
+	add := func(locale string) {
+		if !seen[locale] {
+			seen[locale] = true
+			locales = append(locales, locale)
 		}
 	}
 
-	return nil
+	for locale := range asset.Fields.File {
+		add(locale)
+	}
+	for locale := range asset.Fields.Title {
+		add(locale)
+	}
+	for locale := range asset.Fields.Description {
+		add(locale)
+	}
+
+	sort.Strings(locales)
+
+	return locales
+}
+
+// Title returns the title of the given locale
+func (asset *Asset) Title(locale string) string {
+	if asset.Fields == nil {
+		return ""
+	}
+
+	return asset.Fields.Title[locale]
+}
+
+// Description returns the description of the given locale
+func (asset *Asset) Description(locale string) string {
+	if asset.Fields == nil {
+		return ""
+	}
+
+	return asset.Fields.Description[locale]
+}
+
+// File returns the file of the given locale, nil if the locale has no file
+func (asset *Asset) File(locale string) *File {
+	if asset.Fields == nil {
+		return nil
+	}
+
+	return asset.Fields.File[locale]
+}
+
+// SetTitle sets the title of the given locale
+func (asset *Asset) SetTitle(locale, title string) {
+	asset.initFields()
+	if asset.Fields.Title == nil {
+		asset.Fields.Title = map[string]string{}
+	}
+
+	asset.Fields.Title[locale] = title
+}
+
+// SetDescription sets the description of the given locale
+func (asset *Asset) SetDescription(locale, description string) {
+	asset.initFields()
+	if asset.Fields.Description == nil {
+		asset.Fields.Description = map[string]string{}
+	}
+
+	asset.Fields.Description[locale] = description
+}
+
+// SetFile sets the file of the given locale
+func (asset *Asset) SetFile(locale string, file *File) {
+	asset.initFields()
+	if asset.Fields.File == nil {
+		asset.Fields.File = map[string]*File{}
+	}
+
+	asset.Fields.File[locale] = file
+}
+
+func (asset *Asset) initFields() {
+	if asset.Fields == nil {
+		asset.Fields = &FileFields{}
+	}
 }
 
 // GetVersion returns entity version
@@ -215,20 +332,22 @@ func (service *AssetsService) Delete(spaceID string, asset *Asset) error {
 	return service.c.do(req, nil)
 }
 
-// Process the asset
-func (service *AssetsService) Process(spaceID string, asset *Asset) error {
-	path := fmt.Sprintf("/spaces/%s/assets/%s/files/%s/process", spaceID, asset.Sys.ID, asset.locale)
-	method := "PUT"
-
-	req, err := service.c.newRequest(method, path, nil, nil)
-	if err != nil {
-		return err
+// Process the files of the given locales, every locale with a file when none is given
+func (service *AssetsService) Process(spaceID string, asset *Asset, locales ...string) error {
+	if len(locales) == 0 && asset.Fields != nil {
+		for locale := range asset.Fields.File {
+			locales = append(locales, locale)
+		}
+		sort.Strings(locales)
 	}
 
-	version := strconv.Itoa(asset.Sys.Version)
-	req.Header.Set("X-Contentful-Version", version)
+	for _, locale := range locales {
+		if err := service.process(spaceID, asset.Sys.ID, locale, asset.Sys.Version); err != nil {
+			return err
+		}
+	}
 
-	return service.c.do(req, nil)
+	return nil
 }
 
 // Publish published the asset
@@ -0,0 +1,501 @@
+package contentful
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// AssetManifestFileName is the name of the manifest written to the download directory
+const AssetManifestFileName = "manifest.json"
+
+// AssetManifest maps asset ids and locales to the downloaded files
+type AssetManifest struct {
+	Assets map[string]map[string]*AssetManifestFile `json:"assets"`
+}
+
+// AssetManifestFile describes a downloaded asset file
+type AssetManifestFile struct {
+	// Path of the file relative to the download directory
+	Path    string `json:"path"`
+	URL     string `json:"url"`
+	Version int    `json:"version"`
+	Size    int64  `json:"size"`
+	SHA256  string `json:"sha256"`
+}
+
+// AssetDownloadError is returned when the file of an asset locale can not be downloaded
+type AssetDownloadError struct {
+	AssetID string
+	Locale  string
+	Err     error
+}
+
+func (e AssetDownloadError) Error() string {
+	return fmt.Sprintf("downloading asset %s file for locale %q failed: %s", e.AssetID, e.Locale, e.Err)
+}
+
+// Unwrap returns the cause of the failure
+func (e AssetDownloadError) Unwrap() error {
+	return e.Err
+}
+
+// AssetDownloadResult summarizes a download run
+type AssetDownloadResult struct {
+	Manifest   *AssetManifest
+	Downloaded int
+	Skipped    int
+}
+
+// AssetDownloader mirrors the asset files of a space to a local directory.
+// Files are stored as `<Dir>/<asset id>/<locale>/<file name>` and listed in a manifest,
+// files which did not change since the previous run are skipped.
+type AssetDownloader struct {
+	// Dir is the download directory
+	Dir string
+
+	// Concurrency is the number of parallel downloads, defaults to 4
+	Concurrency int
+
+	// Locales restricts the downloaded locales, all locales are downloaded when empty
+	Locales []string
+
+	c *Client
+}
+
+type assetDownload struct {
+	assetID  string
+	locale   string
+	version  int
+	file     *File
+	previous *AssetManifestFile
+}
+
+// NewDownloader returns an asset downloader writing to the given directory
+func (service *AssetsService) NewDownloader(dir string) *AssetDownloader {
+	return &AssetDownloader{
+		Dir:         dir,
+		Concurrency: 4,
+		c:           service.c,
+	}
+}
+
+// Entry returns the manifest entry of the asset locale, nil if it has not been downloaded
+func (manifest *AssetManifest) Entry(assetID, locale string) *AssetManifestFile {
+	return manifest.Assets[assetID][locale]
+}
+
+func (manifest *AssetManifest) set(assetID, locale string, file *AssetManifestFile) {
+	if manifest.Assets[assetID] == nil {
+		manifest.Assets[assetID] = map[string]*AssetManifestFile{}
+	}
+
+	manifest.Assets[assetID][locale] = file
+}
+
+// ReadAssetManifest reads the manifest of the given download directory.
+// An empty manifest is returned if the directory has none yet.
+func ReadAssetManifest(dir string) (*AssetManifest, error) {
+	manifest := &AssetManifest{Assets: map[string]map[string]*AssetManifestFile{}}
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, AssetManifestFileName))
+	if os.IsNotExist(err) {
+		return manifest, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, err
+	}
+
+	if manifest.Assets == nil {
+		manifest.Assets = map[string]map[string]*AssetManifestFile{}
+	}
+
+	return manifest, nil
+}
+
+func (d *AssetDownloader) writeManifest(manifest *AssetManifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// write and rename, an interrupted run never leaves a truncated manifest
+	path := filepath.Join(d.Dir, AssetManifestFileName)
+	if err := ioutil.WriteFile(path+".tmp", data, 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(path+".tmp", path)
+}
+
+func (d *AssetDownloader) listAssets(ctx context.Context, spaceID string) ([]*Asset, error) {
+	col := d.c.Assets.List(spaceID)
+	if col.req == nil {
+		return nil, fmt.Errorf("can not list assets of space %s", spaceID)
+	}
+
+	// the delivery apis return a single locale by default
+	if d.c.api != "CMA" {
+		col.Locale("*")
+	}
+
+	col.req = col.req.WithContext(ctx)
+
+	assets := []*Asset{}
+	for {
+		if _, err := col.Next(); err != nil {
+			return nil, err
+		}
+
+		assets = append(assets, col.ToAsset()...)
+
+		if len(col.Items) == 0 || col.Skip+len(col.Items) >= col.Total {
+			return assets, nil
+		}
+	}
+}
+
+func (d *AssetDownloader) downloads(assets []*Asset) []*assetDownload {
+	wanted := map[string]bool{}
+	for _, locale := range d.Locales {
+		wanted[locale] = true
+	}
+
+	downloads := []*assetDownload{}
+	for _, asset := range assets {
+		if asset.Sys == nil || asset.Fields == nil {
+			continue
+		}
+
+		version := asset.Sys.Version
+		if version == 0 {
+			version = asset.Sys.Revision
+		}
+
+		locales := []string{}
+		for locale := range asset.Fields.File {
+			locales = append(locales, locale)
+		}
+		sort.Strings(locales)
+
+		for _, locale := range locales {
+			file := asset.Fields.File[locale]
+			if file == nil || file.URL == "" || (len(wanted) > 0 && !wanted[locale]) {
+				continue
+			}
+
+			downloads = append(downloads, &assetDownload{
+				assetID: asset.Sys.ID,
+				locale:  locale,
+				version: version,
+				file:    file,
+			})
+		}
+	}
+
+	return downloads
+}
+
+// unchanged reports whether the manifest entry still matches the asset file on disk,
+// the checksum of the file is compared so that corrupted files are downloaded again
+func (d *AssetDownloader) unchanged(entry *AssetManifestFile, download *assetDownload) bool {
+	if entry == nil || entry.Version != download.version {
+		return false
+	}
+
+	if download.file.Detail != nil && download.file.Detail.Size != 0 && int64(download.file.Detail.Size) != entry.Size {
+		return false
+	}
+
+	path := filepath.Join(d.Dir, filepath.FromSlash(entry.Path))
+	info, err := os.Stat(path)
+	if err != nil || info.Size() != entry.Size {
+		return false
+	}
+
+	hasher := sha256.New()
+	if _, err := hashFile(path, hasher); err != nil {
+		return false
+	}
+
+	return hex.EncodeToString(hasher.Sum(nil)) == entry.SHA256
+}
+
+func assetFileURL(file *File) string {
+	if strings.HasPrefix(file.URL, "//") {
+		return "https:" + file.URL
+	}
+
+	return file.URL
+}
+
+// safePathSegment reports whether the name can be used as a single directory name
+// which stays inside of its parent directory
+func safePathSegment(name string) bool {
+	return name != "" && name != "." && name != ".." && !strings.ContainsAny(name, "/\\\x00")
+}
+
+func assetFilePath(download *assetDownload) (string, error) {
+	if !safePathSegment(download.assetID) {
+		return "", fmt.Errorf("asset id %q can not be used as a directory name", download.assetID)
+	}
+
+	if !safePathSegment(download.locale) {
+		return "", fmt.Errorf("locale %q can not be used as a directory name", download.locale)
+	}
+
+	name := filepath.Base(strings.Replace(download.file.Name, "\\", "/", -1))
+	if !safePathSegment(name) {
+		name = "file"
+	}
+
+	return filepath.Join(download.assetID, download.locale, name), nil
+}
+
+// safeRelativePath reports whether the relative path stays inside of its base directory
+func safeRelativePath(path string) bool {
+	clean := filepath.Clean(path)
+	return clean != ".." && !strings.HasPrefix(clean, ".."+string(filepath.Separator))
+}
+
+// removeStaleParts removes the partial downloads of other versions of the file
+func removeStaleParts(path, keep string) error {
+	infos, err := ioutil.ReadDir(filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+
+	prefix := filepath.Base(path) + ".v"
+	for _, info := range infos {
+		name := info.Name()
+		if !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ".part") || name == filepath.Base(keep) {
+			continue
+		}
+
+		if err := os.Remove(filepath.Join(filepath.Dir(path), name)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fetch downloads the file to `path`. A partial download of the same asset version
+// left by an interrupted run is resumed with a range request when the server supports it.
+func (d *AssetDownloader) fetch(ctx context.Context, download *assetDownload, path string) (int64, string, error) {
+	partial := fmt.Sprintf("%s.v%d.part", path, download.version)
+	if err := removeStaleParts(path, partial); err != nil {
+		return 0, "", err
+	}
+
+	hasher := sha256.New()
+	offset, err := hashFile(partial, hasher)
+	if err != nil {
+		return 0, "", err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, assetFileURL(download.file), nil)
+	if err != nil {
+		return 0, "", err
+	}
+	req = req.WithContext(ctx)
+
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	res, err := d.c.client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer res.Body.Close()
+
+	flags := os.O_CREATE | os.O_WRONLY
+	switch {
+	case res.StatusCode == http.StatusPartialContent && offset > 0:
+		flags |= os.O_APPEND
+	case res.StatusCode == http.StatusOK:
+		// the server ignored the range, start over
+		flags |= os.O_TRUNC
+		offset = 0
+		hasher.Reset()
+	default:
+		return 0, "", fmt.Errorf("unexpected status %s", res.Status)
+	}
+
+	out, err := os.OpenFile(partial, flags, 0644)
+	if err != nil {
+		return 0, "", err
+	}
+
+	n, err := io.Copy(io.MultiWriter(out, hasher), res.Body)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return 0, "", err
+	}
+
+	// a truncated or corrupted partial download is not kept for the next run
+	if download.file.Detail != nil && download.file.Detail.Size != 0 && offset+n != int64(download.file.Detail.Size) {
+		os.Remove(partial)
+		return 0, "", fmt.Errorf("downloaded %d bytes, expected %d", offset+n, download.file.Detail.Size)
+	}
+
+	if err := os.Rename(partial, path); err != nil {
+		return 0, "", err
+	}
+
+	return offset + n, hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// hashFile writes the content of the file to the hasher, a missing file is empty
+func hashFile(path string, hasher hash.Hash) (int64, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	return io.Copy(hasher, f)
+}
+
+// Download mirrors the asset files of the space. The manifest is updated after
+// every file, so an interrupted run resumes where it stopped. Failed files are
+// reported with an AssetDownloadError after every other file has been processed.
+func (d *AssetDownloader) Download(ctx context.Context, spaceID string) (*AssetDownloadResult, error) {
+	if err := os.MkdirAll(d.Dir, 0755); err != nil {
+		return nil, err
+	}
+
+	manifest, err := ReadAssetManifest(d.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	assets, err := d.listAssets(ctx, spaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &AssetDownloadResult{Manifest: manifest}
+
+	concurrency := d.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+	)
+
+	queue := make(chan *assetDownload)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for download := range queue {
+				entry, err := d.download(ctx, download)
+
+				mu.Lock()
+				if err == nil {
+					manifest.set(download.assetID, download.locale, entry)
+					result.Downloaded++
+					err = d.writeManifest(manifest)
+				}
+				if err != nil && firstErr == nil {
+					firstErr = AssetDownloadError{AssetID: download.assetID, Locale: download.locale, Err: err}
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, download := range d.downloads(assets) {
+		mu.Lock()
+		download.previous = manifest.Entry(download.assetID, download.locale)
+		mu.Unlock()
+
+		if d.unchanged(download.previous, download) {
+			mu.Lock()
+			result.Skipped++
+			mu.Unlock()
+			continue
+		}
+
+		select {
+		case queue <- download:
+		case <-ctx.Done():
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	close(queue)
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return result, ctx.Err()
+	}
+
+	if firstErr != nil {
+		return result, firstErr
+	}
+
+	return result, d.writeManifest(manifest)
+}
+
+func (d *AssetDownloader) download(ctx context.Context, download *assetDownload) (*AssetManifestFile, error) {
+	rel, err := assetFilePath(download)
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(d.Dir, rel)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+
+	size, checksum, err := d.fetch(ctx, download, path)
+	if err != nil {
+		return nil, err
+	}
+
+	// the file of a previous version with another name is replaced
+	if previous := download.previous; previous != nil && previous.Path != filepath.ToSlash(rel) {
+		if old := filepath.FromSlash(previous.Path); !filepath.IsAbs(old) && safeRelativePath(old) {
+			os.Remove(filepath.Join(d.Dir, old))
+		}
+	}
+
+	return &AssetManifestFile{
+		Path:    filepath.ToSlash(rel),
+		URL:     download.file.URL,
+		Version: download.version,
+		Size:    size,
+		SHA256:  checksum,
+	}, nil
+}
@@ -0,0 +1,330 @@
+package contentful
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type assetMirrorServer struct {
+	sync.Mutex
+	server   *httptest.Server
+	versions map[string]int
+	binaries map[string]string
+	files    map[string]map[string]string
+	fetched  []string
+	ranges   []string
+}
+
+func newAssetMirrorServer() *assetMirrorServer {
+	s := &assetMirrorServer{
+		versions: map[string]int{"nyancat": 3, "happycat": 5},
+		binaries: map[string]string{
+			"/nyancat/en-US.png":  "nyan cat binary",
+			"/nyancat/de-DE.png":  "nyan katze binary",
+			"/happycat/en-US.jpg": "happy cat binary",
+		},
+		files: map[string]map[string]string{
+			"nyancat":  {"en-US": "/nyancat/en-US.png", "de-DE": "/nyancat/de-DE.png"},
+			"happycat": {"en-US": "/happycat/en-US.jpg"},
+		},
+	}
+
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+func (s *assetMirrorServer) asset(id string, files map[string]string) map[string]interface{} {
+	file := map[string]interface{}{}
+	for locale, path := range files {
+		file[locale] = map[string]interface{}{
+			"fileName":    filepath.Base(path),
+			"contentType": "image/png",
+			"url":         s.server.URL + path,
+			"details":     map[string]interface{}{"size": len(s.binaries[path])},
+		}
+	}
+
+	return map[string]interface{}{
+		"sys":    map[string]interface{}{"id": id, "type": "Asset", "version": s.versions[id]},
+		"fields": map[string]interface{}{"file": file},
+	}
+}
+
+func (s *assetMirrorServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.Lock()
+	defer s.Unlock()
+
+	if r.URL.Path == "/spaces/"+spaceID+"/assets" {
+		// one asset per page
+		items := []interface{}{
+			s.asset("nyancat", s.files["nyancat"]),
+			s.asset("happycat", s.files["happycat"]),
+		}
+
+		skip, _ := strconv.Atoi(r.URL.Query().Get("skip"))
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"total": len(items),
+			"skip":  skip,
+			"limit": 1,
+			"items": items[skip : skip+1],
+		})
+		return
+	}
+
+	binary, ok := s.binaries[r.URL.Path]
+	if !ok {
+		w.WriteHeader(404)
+		return
+	}
+
+	s.fetched = append(s.fetched, r.URL.Path)
+
+	if rng := r.Header.Get("Range"); rng != "" {
+		s.ranges = append(s.ranges, rng)
+		offset, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(rng, "bytes="), "-"))
+		w.WriteHeader(http.StatusPartialContent)
+		fmt.Fprint(w, binary[offset:])
+		return
+	}
+
+	fmt.Fprint(w, binary)
+}
+
+func (s *assetMirrorServer) resetRequests() {
+	s.Lock()
+	defer s.Unlock()
+	s.fetched = nil
+	s.ranges = nil
+}
+
+func mirrorDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "contentful-assets")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return dir
+}
+
+func TestAssetDownloaderDownload(t *testing.T) {
+	assert := assert.New(t)
+
+	s := newAssetMirrorServer()
+	defer s.server.Close()
+
+	dir := mirrorDir(t)
+	defer os.RemoveAll(dir)
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = s.server.URL
+
+	downloader := cma.Assets.NewDownloader(dir)
+
+	result, err := downloader.Download(context.Background(), spaceID)
+	assert.Nil(err)
+	assert.Equal(3, result.Downloaded)
+	assert.Equal(0, result.Skipped)
+	assert.Equal(3, len(s.fetched))
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "nyancat", "de-DE", "de-DE.png"))
+	assert.Nil(err)
+	assert.Equal("nyan katze binary", string(data))
+
+	manifest, err := ReadAssetManifest(dir)
+	assert.Nil(err)
+
+	entry := manifest.Entry("happycat", "en-US")
+	assert.Equal("happycat/en-US/en-US.jpg", entry.Path)
+	assert.Equal(5, entry.Version)
+	assert.Equal(int64(len("happy cat binary")), entry.Size)
+	sum := sha256.Sum256([]byte("happy cat binary"))
+	assert.Equal(hex.EncodeToString(sum[:]), entry.SHA256)
+
+	// unchanged files are skipped
+	s.resetRequests()
+	result, err = downloader.Download(context.Background(), spaceID)
+	assert.Nil(err)
+	assert.Equal(0, result.Downloaded)
+	assert.Equal(3, result.Skipped)
+	assert.Equal(0, len(s.fetched))
+
+	// a new version and a deleted file are downloaded again
+	s.resetRequests()
+	s.versions["nyancat"] = 4
+	os.Remove(filepath.Join(dir, "happycat", "en-US", "en-US.jpg"))
+
+	result, err = downloader.Download(context.Background(), spaceID)
+	assert.Nil(err)
+	assert.Equal(3, result.Downloaded)
+	assert.Equal(4, result.Manifest.Entry("nyancat", "en-US").Version)
+}
+
+func TestAssetFileURL(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("https://images.ctfassets.net/id1/cat.png", assetFileURL(&File{URL: "//images.ctfassets.net/id1/cat.png"}))
+	assert.Equal("http://localhost/cat.png", assetFileURL(&File{URL: "http://localhost/cat.png"}))
+}
+
+func TestAssetDownloaderResume(t *testing.T) {
+	assert := assert.New(t)
+
+	s := newAssetMirrorServer()
+	defer s.server.Close()
+
+	dir := mirrorDir(t)
+	defer os.RemoveAll(dir)
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = s.server.URL
+
+	// leftovers of an interrupted run
+	partial := filepath.Join(dir, "happycat", "en-US", "en-US.jpg.v5.part")
+	assert.Nil(os.MkdirAll(filepath.Dir(partial), 0755))
+	assert.Nil(ioutil.WriteFile(partial, []byte("happy "), 0644))
+
+	downloader := cma.Assets.NewDownloader(dir)
+	downloader.Locales = []string{"en-US"}
+
+	result, err := downloader.Download(context.Background(), spaceID)
+	assert.Nil(err)
+	assert.Equal(2, result.Downloaded)
+	assert.Equal([]string{"bytes=6-"}, s.ranges)
+	assert.Nil(result.Manifest.Entry("nyancat", "de-DE"))
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "happycat", "en-US", "en-US.jpg"))
+	assert.Nil(err)
+	assert.Equal("happy cat binary", string(data))
+
+	sum := sha256.Sum256([]byte("happy cat binary"))
+	assert.Equal(hex.EncodeToString(sum[:]), result.Manifest.Entry("happycat", "en-US").SHA256)
+
+	_, err = os.Stat(partial)
+	assert.True(os.IsNotExist(err))
+}
+
+func TestAssetDownloaderErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	s := newAssetMirrorServer()
+	defer s.server.Close()
+	delete(s.binaries, "/nyancat/de-DE.png")
+
+	dir := mirrorDir(t)
+	defer os.RemoveAll(dir)
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = s.server.URL
+
+	result, err := cma.Assets.NewDownloader(dir).Download(context.Background(), spaceID)
+	assert.NotNil(err)
+	downloadErr, ok := err.(AssetDownloadError)
+	assert.True(ok)
+	assert.Equal("nyancat", downloadErr.AssetID)
+	assert.Equal("de-DE", downloadErr.Locale)
+
+	// the other files are kept in the manifest
+	assert.Equal(2, result.Downloaded)
+	manifest, err := ReadAssetManifest(dir)
+	assert.Nil(err)
+	assert.NotNil(manifest.Entry("happycat", "en-US"))
+}
+
+func TestAssetDownloaderVerify(t *testing.T) {
+	assert := assert.New(t)
+
+	s := newAssetMirrorServer()
+	defer s.server.Close()
+
+	dir := mirrorDir(t)
+	defer os.RemoveAll(dir)
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = s.server.URL
+
+	// partial download of an older version
+	stale := filepath.Join(dir, "happycat", "en-US", "en-US.jpg.v4.part")
+	assert.Nil(os.MkdirAll(filepath.Dir(stale), 0755))
+	assert.Nil(ioutil.WriteFile(stale, []byte("old"), 0644))
+
+	downloader := cma.Assets.NewDownloader(dir)
+	downloader.Locales = []string{"en-US"}
+
+	_, err := downloader.Download(context.Background(), spaceID)
+	assert.Nil(err)
+	assert.Equal(0, len(s.ranges))
+
+	_, err = os.Stat(stale)
+	assert.True(os.IsNotExist(err))
+
+	// a corrupted file with the same size is downloaded again
+	s.resetRequests()
+	path := filepath.Join(dir, "happycat", "en-US", "en-US.jpg")
+	assert.Nil(ioutil.WriteFile(path, []byte("grumpy cat binar"), 0644))
+
+	result, err := downloader.Download(context.Background(), spaceID)
+	assert.Nil(err)
+	assert.Equal(1, result.Downloaded)
+	assert.Equal([]string{"/happycat/en-US.jpg"}, s.fetched)
+
+	data, err := ioutil.ReadFile(path)
+	assert.Nil(err)
+	assert.Equal("happy cat binary", string(data))
+
+	// the file of the previous version is removed after a rename
+	s.versions["happycat"] = 6
+	s.binaries["/happycat/happy.jpg"] = "happy cat binary"
+	s.files["happycat"] = map[string]string{"en-US": "/happycat/happy.jpg"}
+
+	result, err = downloader.Download(context.Background(), spaceID)
+	assert.Nil(err)
+	assert.Equal("happycat/en-US/happy.jpg", result.Manifest.Entry("happycat", "en-US").Path)
+
+	_, err = os.Stat(path)
+	assert.True(os.IsNotExist(err))
+}
+
+func TestAssetDownloaderUnsafePath(t *testing.T) {
+	assert := assert.New(t)
+
+	s := newAssetMirrorServer()
+	defer s.server.Close()
+	s.files["happycat"] = map[string]string{"../x": "/happycat/en-US.jpg"}
+
+	dir := mirrorDir(t)
+	defer os.RemoveAll(dir)
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = s.server.URL
+
+	_, err := cma.Assets.NewDownloader(dir).Download(context.Background(), spaceID)
+	assert.NotNil(err)
+	downloadErr, ok := err.(AssetDownloadError)
+	assert.True(ok)
+	assert.Equal("happycat", downloadErr.AssetID)
+	assert.Equal("../x", downloadErr.Locale)
+
+	_, err = os.Stat(filepath.Join(dir, "x"))
+	assert.True(os.IsNotExist(err))
+	assert.NotContains(s.fetched, "/happycat/en-US.jpg")
+}
@@ -0,0 +1,187 @@
+package contentful
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// AssetKeyMaxTTL is the longest lifetime of an asset key accepted by the API
+	AssetKeyMaxTTL = 48 * time.Hour
+
+	// assetKeyRefreshMargin renews a cached key before it expires
+	assetKeyRefreshMargin = 5 * time.Minute
+)
+
+// AssetKeysService service
+type AssetKeysService service
+
+// AssetKey model holds the policy and secret used to sign embargoed asset urls
+type AssetKey struct {
+	Policy string `json:"policy"`
+	Secret string `json:"secret"`
+
+	// ExpiresAt is the expiry requested when the key was created,
+	// signed urls can not outlive it
+	ExpiresAt time.Time `json:"-"`
+}
+
+// Create returns a new asset key expiring at the given time, at most 48 hours from now
+func (service *AssetKeysService) Create(spaceID string, expiresAt time.Time) (*AssetKey, error) {
+	if ttl := time.Until(expiresAt); ttl <= 0 || ttl > AssetKeyMaxTTL {
+		return nil, fmt.Errorf("asset key expiry must be in the next %s", AssetKeyMaxTTL)
+	}
+
+	bytesArray, err := json.Marshal(map[string]int64{
+		"expiresAt": expiresAt.Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("/spaces/%s/environments/%s/asset_keys", spaceID, service.c.Environment)
+
+	req, err := service.c.newRequest(http.MethodPost, path, nil, bytes.NewReader(bytesArray))
+	if err != nil {
+		return nil, err
+	}
+
+	var key AssetKey
+	if err := service.c.do(req, &key); err != nil {
+		return nil, err
+	}
+
+	key.ExpiresAt = time.Unix(expiresAt.Unix(), 0)
+
+	return &key, nil
+}
+
+func encodeJWTSegment(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// SignURL returns the asset url signed with the key, valid until the given time.
+// Protocol relative urls, as found in `File.URL`, are signed as https urls.
+func (key *AssetKey) SignURL(rawURL string, expiresAt time.Time) (string, error) {
+	if !key.ExpiresAt.IsZero() && expiresAt.After(key.ExpiresAt) {
+		return "", fmt.Errorf("signed url can not expire after its asset key (%s)", key.ExpiresAt.Format(time.RFC3339))
+	}
+
+	if strings.HasPrefix(rawURL, "//") {
+		rawURL = "https:" + rawURL
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	subject := *u
+	subject.RawQuery = ""
+	subject.Fragment = ""
+
+	header, err := encodeJWTSegment(map[string]string{"alg": "HS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := encodeJWTSegment(map[string]interface{}{
+		"sub": subject.String(),
+		"exp": expiresAt.Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	mac := hmac.New(sha256.New, []byte(key.Secret))
+	mac.Write([]byte(header + "." + claims))
+	signature := base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+
+	values := u.Query()
+	values.Set("token", header+"."+claims+"."+signature)
+	values.Set("policy", key.Policy)
+	u.RawQuery = values.Encode()
+
+	return u.String(), nil
+}
+
+// AssetURLSigner signs embargoed asset urls of a space.
+// It creates asset keys on demand and reuses them until they are close to expiry.
+type AssetURLSigner struct {
+	// KeyTTL is the lifetime of the created asset keys, defaults to AssetKeyMaxTTL
+	KeyTTL time.Duration
+
+	service *AssetKeysService
+	spaceID string
+
+	mu  sync.Mutex
+	key *AssetKey
+}
+
+// NewSigner returns an url signer for the embargoed assets of the space
+func (service *AssetKeysService) NewSigner(spaceID string) *AssetURLSigner {
+	return &AssetURLSigner{
+		KeyTTL:  AssetKeyMaxTTL,
+		service: service,
+		spaceID: spaceID,
+	}
+}
+
+func (signer *AssetURLSigner) assetKey(expiresAt time.Time) (*AssetKey, error) {
+	signer.mu.Lock()
+	defer signer.mu.Unlock()
+
+	if signer.key != nil && !signer.key.ExpiresAt.Before(expiresAt.Add(assetKeyRefreshMargin)) {
+		return signer.key, nil
+	}
+
+	// stay clear of the maximum, the api compares it with its own clock
+	keyExpiresAt := time.Now().Add(signer.KeyTTL - time.Minute)
+	if keyExpiresAt.Before(expiresAt) {
+		return nil, fmt.Errorf("signed urls can not be valid for longer than %s", signer.KeyTTL)
+	}
+
+	key, err := signer.service.Create(signer.spaceID, keyExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	signer.key = key
+
+	return key, nil
+}
+
+// SignURL returns the asset url signed for the given duration
+func (signer *AssetURLSigner) SignURL(rawURL string, ttl time.Duration) (string, error) {
+	expiresAt := time.Now().Add(ttl)
+
+	key, err := signer.assetKey(expiresAt)
+	if err != nil {
+		return "", err
+	}
+
+	return key.SignURL(rawURL, expiresAt)
+}
+
+// SignFile returns the url of the file signed for the given duration
+func (signer *AssetURLSigner) SignFile(file *File, ttl time.Duration) (string, error) {
+	if file == nil || file.URL == "" {
+		return "", fmt.Errorf("file has no url, it has to be processed first")
+	}
+
+	return signer.SignURL(file.URL, ttl)
+}
@@ -0,0 +1,160 @@
+package contentful
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func verifyAssetToken(t *testing.T, signed, secret string) (url.Values, map[string]interface{}) {
+	u, err := url.Parse(signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	query := u.Query()
+	parts := strings.Split(query.Get("token"), ".")
+	if len(parts) != 3 {
+		t.Fatalf("invalid token %q", query.Get("token"))
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if base64.RawURLEncoding.EncodeToString(mac.Sum(nil)) != parts[2] {
+		t.Fatal("invalid token signature")
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims := map[string]interface{}{}
+	if err := json.Unmarshal(data, &claims); err != nil {
+		t.Fatal(err)
+	}
+
+	return query, claims
+}
+
+func TestAssetKeysServiceCreate(t *testing.T) {
+	assert := assert.New(t)
+
+	expiresAt := time.Now().Add(time.Hour)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("POST", r.Method)
+		assert.Equal("/spaces/"+spaceID+"/environments/master/asset_keys", r.URL.Path)
+
+		var payload map[string]int64
+		err := json.NewDecoder(r.Body).Decode(&payload)
+		assert.Nil(err)
+		assert.Equal(expiresAt.Unix(), payload["expiresAt"])
+
+		fmt.Fprintln(w, readTestData("asset_key.json"))
+	})
+
+	// test server
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// cda client
+	cda := NewCDA(CDAToken)
+	cda.BaseURL = server.URL
+
+	key, err := cda.AssetKeys.Create(spaceID, expiresAt)
+	assert.Nil(err)
+	assert.Equal("s3cr3t", key.Secret)
+	assert.Equal(expiresAt.Unix(), key.ExpiresAt.Unix())
+
+	_, err = cda.AssetKeys.Create(spaceID, time.Now().Add(49*time.Hour))
+	assert.NotNil(err)
+
+	_, err = cda.AssetKeys.Create(spaceID, time.Now().Add(-time.Minute))
+	assert.NotNil(err)
+}
+
+func TestAssetKeySignURL(t *testing.T) {
+	assert := assert.New(t)
+
+	key := &AssetKey{
+		Policy:    "cG9saWN5",
+		Secret:    "s3cr3t",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	expiresAt := time.Now().Add(10 * time.Minute)
+
+	signed, err := key.SignURL("//images.secure.ctfassets.net/id1/doc/report.png?w=200", expiresAt)
+	assert.Nil(err)
+	assert.True(strings.HasPrefix(signed, "https://images.secure.ctfassets.net/id1/doc/report.png?"))
+
+	query, claims := verifyAssetToken(t, signed, "s3cr3t")
+	assert.Equal("200", query.Get("w"))
+	assert.Equal("cG9saWN5", query.Get("policy"))
+	assert.Equal("https://images.secure.ctfassets.net/id1/doc/report.png", claims["sub"])
+	assert.Equal(float64(expiresAt.Unix()), claims["exp"])
+
+	_, err = key.SignURL("//images.secure.ctfassets.net/id1/doc/report.png", time.Now().Add(2*time.Hour))
+	assert.NotNil(err)
+}
+
+func TestAssetURLSignerCachesKeys(t *testing.T) {
+	assert := assert.New(t)
+
+	created := 0
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		created++
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.Contains(string(body), "expiresAt")
+
+		fmt.Fprintf(w, `{"policy": "policy-%d", "secret": "secret-%d"}`, created, created)
+	})
+
+	// test server
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// cda client
+	cda := NewCDA(CDAToken)
+	cda.BaseURL = server.URL
+
+	signer := cda.AssetKeys.NewSigner(spaceID)
+	signer.KeyTTL = time.Hour
+
+	file := &File{URL: "//assets.secure.ctfassets.net/id1/doc/report.pdf"}
+
+	signed, err := signer.SignFile(file, 10*time.Minute)
+	assert.Nil(err)
+	query, _ := verifyAssetToken(t, signed, "secret-1")
+	assert.Equal("policy-1", query.Get("policy"))
+
+	// the cached key outlives the url
+	signed, err = signer.SignFile(file, 30*time.Minute)
+	assert.Nil(err)
+	verifyAssetToken(t, signed, "secret-1")
+	assert.Equal(1, created)
+
+	// the cached key is too close to its expiry
+	signed, err = signer.SignFile(file, 56*time.Minute)
+	assert.Nil(err)
+	query, _ = verifyAssetToken(t, signed, "secret-2")
+	assert.Equal("policy-2", query.Get("policy"))
+	assert.Equal(2, created)
+
+	_, err = signer.SignFile(file, 2*time.Hour)
+	assert.NotNil(err)
+
+	_, err = signer.SignFile(&File{}, time.Minute)
+	assert.NotNil(err)
+}
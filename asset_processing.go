@@ -0,0 +1,188 @@
+package contentful
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultAssetProcessingInterval is the first delay between two asset polls
+	DefaultAssetProcessingInterval = 500 * time.Millisecond
+
+	// DefaultAssetProcessingMaxInterval caps the exponential backoff of asset polls
+	DefaultAssetProcessingMaxInterval = 10 * time.Second
+
+	// DefaultAssetProcessingMaxPolls bounds the polls of an asset, about five minutes with the default intervals
+	DefaultAssetProcessingMaxPolls = 35
+)
+
+// AssetProcessingOptions model, zero values use the defaults
+type AssetProcessingOptions struct {
+	// Interval is the first delay between two polls, doubled after each poll
+	Interval time.Duration
+
+	// MaxInterval caps the delay between two polls
+	MaxInterval time.Duration
+
+	// MaxPolls is the number of polls after which waiting for a file fails
+	MaxPolls int
+}
+
+// withDefaults returns a copy of the options with the unset values filled in
+func (options *AssetProcessingOptions) withDefaults() AssetProcessingOptions {
+	o := AssetProcessingOptions{}
+	if options != nil {
+		o = *options
+	}
+
+	if o.Interval <= 0 {
+		o.Interval = DefaultAssetProcessingInterval
+	}
+
+	if o.MaxInterval <= 0 {
+		o.MaxInterval = DefaultAssetProcessingMaxInterval
+	}
+
+	if o.MaxPolls <= 0 {
+		o.MaxPolls = DefaultAssetProcessingMaxPolls
+	}
+
+	return o
+}
+
+// AssetProcessingError is returned when the file of an asset locale can not be processed
+type AssetProcessingError struct {
+	AssetID string
+	Locale  string
+	Err     error
+}
+
+func (e AssetProcessingError) Error() string {
+	return fmt.Sprintf("processing file of asset %q for locale %q failed: %s", e.AssetID, e.Locale, e.Err)
+}
+
+// Unwrap returns the cause of the failure
+func (e AssetProcessingError) Unwrap() error {
+	return e.Err
+}
+
+// fileProcessed reports whether the file of the locale has been processed
+func fileProcessed(asset *Asset, locale string) bool {
+	file := asset.File(locale)
+	return file != nil && file.URL != ""
+}
+
+// fileProcessable reports whether the file of the locale still refers to an unprocessed binary
+func fileProcessable(asset *Asset, locale string) bool {
+	file := asset.File(locale)
+	return file != nil && (file.UploadURL != "" || file.UploadFrom != nil)
+}
+
+func (service *AssetsService) process(spaceID, assetID, locale string, version int) error {
+	path := fmt.Sprintf("/spaces/%s/assets/%s/files/%s/process", spaceID, assetID, locale)
+
+	req, err := service.c.newRequest(http.MethodPut, path, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("X-Contentful-Version", strconv.Itoa(version))
+
+	return service.c.do(req, nil)
+}
+
+// ProcessAndWait processes the files of the given locales, every locale with an
+// unprocessed file when none is given, and polls the asset with exponential backoff
+// until each of them has a URL. The asset is refreshed with its final version.
+// The polls are configured by options, nil uses the defaults.
+//
+// An AssetProcessingError is returned for the first locale whose upload is cleared
+// without a URL, which is how failed processing shows, or which still has no URL
+// after a bounded number of polls.
+func (service *AssetsService) ProcessAndWait(ctx context.Context, spaceID string, asset *Asset, options *AssetProcessingOptions, locales ...string) error {
+	polling := options.withDefaults()
+
+	current, err := service.Get(spaceID, asset.Sys.ID)
+	if err != nil {
+		return err
+	}
+
+	if len(locales) == 0 {
+		for locale := range current.Fields.File {
+			if !fileProcessed(current, locale) {
+				locales = append(locales, locale)
+			}
+		}
+		sort.Strings(locales)
+	}
+
+	pending := []string{}
+	for _, locale := range locales {
+		if fileProcessed(current, locale) {
+			continue
+		}
+
+		if !fileProcessable(current, locale) {
+			return AssetProcessingError{AssetID: current.Sys.ID, Locale: locale, Err: fmt.Errorf("asset has no file to process")}
+		}
+
+		if err := service.process(spaceID, current.Sys.ID, locale, current.Sys.Version); err != nil {
+			return AssetProcessingError{AssetID: current.Sys.ID, Locale: locale, Err: err}
+		}
+
+		pending = append(pending, locale)
+	}
+
+	interval := polling.Interval
+	for polls := 0; len(pending) > 0; polls++ {
+		if polls == polling.MaxPolls {
+			return AssetProcessingError{
+				AssetID: current.Sys.ID,
+				Locale:  pending[0],
+				Err:     fmt.Errorf("file has no url after %d polls", polls),
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+
+		if interval *= 2; interval > polling.MaxInterval {
+			interval = polling.MaxInterval
+		}
+
+		current, err = service.Get(spaceID, asset.Sys.ID)
+		if err != nil {
+			return err
+		}
+
+		remaining := []string{}
+		for _, locale := range pending {
+			if fileProcessed(current, locale) {
+				continue
+			}
+
+			if !fileProcessable(current, locale) {
+				return AssetProcessingError{
+					AssetID: current.Sys.ID,
+					Locale:  locale,
+					Err:     fmt.Errorf("the upload was cleared without a url, the file could not be processed"),
+				}
+			}
+
+			remaining = append(remaining, locale)
+		}
+
+		pending = remaining
+	}
+
+	*asset = *current
+
+	return nil
+}
@@ -0,0 +1,221 @@
+package contentful
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func rawAssetPayload(version int, processed map[string]bool) map[string]interface{} {
+	files := map[string]interface{}{}
+
+	for locale, done := range processed {
+		file := map[string]interface{}{
+			"fileName":    "nyancat-" + locale + ".png",
+			"contentType": "image/png",
+		}
+
+		if done {
+			file["url"] = "//images.ctfassets.net/id1/nyancat/" + locale + ".png"
+		} else {
+			file["upload"] = "https://www.example.com/nyancat-" + locale + ".png"
+		}
+
+		files[locale] = file
+	}
+
+	return map[string]interface{}{
+		"sys": map[string]interface{}{
+			"id":        "nyancat",
+			"type":      "Asset",
+			"version":   version,
+			"createdAt": "2021-03-15T10:00:00.000Z",
+		},
+		"fields": map[string]interface{}{
+			"title": map[string]interface{}{"en-US": "Nyan Cat", "de-DE": "Nyan Katze"},
+			"file":  files,
+		},
+	}
+}
+
+// fastAssetPolling keeps the tests quick
+var fastAssetPolling = &AssetProcessingOptions{Interval: time.Millisecond, MaxInterval: 4 * time.Millisecond}
+
+func TestAssetsServiceProcessAndWait(t *testing.T) {
+	assert := assert.New(t)
+	gets := 0
+	processed := []string{}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			assert.Equal("3", r.Header.Get("X-Contentful-Version"))
+			processed = append(processed, r.URL.Path)
+			w.WriteHeader(204)
+		case "GET":
+			assert.Equal("/spaces/"+spaceID+"/assets/nyancat", r.URL.Path)
+
+			gets++
+			var payload map[string]interface{}
+			switch gets {
+			case 1:
+				payload = rawAssetPayload(3, map[string]bool{"en-US": false, "de-DE": false})
+			case 2:
+				payload = rawAssetPayload(4, map[string]bool{"en-US": true, "de-DE": false})
+			default:
+				payload = rawAssetPayload(5, map[string]bool{"en-US": true, "de-DE": true})
+			}
+
+			json.NewEncoder(w).Encode(payload)
+		}
+	})
+
+	// test server
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	asset := &Asset{Sys: &Sys{ID: "nyancat", Version: 3}}
+	err := cma.Assets.ProcessAndWait(context.Background(), spaceID, asset, fastAssetPolling)
+	assert.Nil(err)
+
+	sort.Strings(processed)
+	assert.Equal([]string{
+		"/spaces/" + spaceID + "/assets/nyancat/files/de-DE/process",
+		"/spaces/" + spaceID + "/assets/nyancat/files/en-US/process",
+	}, processed)
+	assert.Equal(3, gets)
+
+	assert.Equal(5, asset.Sys.Version)
+	assert.Equal("Nyan Cat", asset.Title("en-US"))
+	assert.Equal("//images.ctfassets.net/id1/nyancat/en-US.png", asset.File("en-US").URL)
+	assert.Equal("//images.ctfassets.net/id1/nyancat/de-DE.png", asset.File("de-DE").URL)
+}
+
+func TestAssetsServiceProcessAndWaitGivenLocales(t *testing.T) {
+	assert := assert.New(t)
+	processed := []string{}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			processed = append(processed, r.URL.Path)
+			w.WriteHeader(204)
+		case "GET":
+			done := len(processed) > 0
+			json.NewEncoder(w).Encode(rawAssetPayload(3, map[string]bool{"en-US": false, "de-DE": done}))
+		}
+	})
+
+	// test server
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	asset := &Asset{Sys: &Sys{ID: "nyancat", Version: 3}}
+	err := cma.Assets.ProcessAndWait(context.Background(), spaceID, asset, fastAssetPolling, "de-DE")
+	assert.Nil(err)
+	assert.Equal([]string{"/spaces/" + spaceID + "/assets/nyancat/files/de-DE/process"}, processed)
+	assert.Equal("Nyan Katze", asset.Title("de-DE"))
+	assert.Equal("", asset.File("en-US").URL)
+}
+
+func TestAssetsServiceProcessAndWaitErrors(t *testing.T) {
+	assert := assert.New(t)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			w.WriteHeader(204)
+		case "GET":
+			json.NewEncoder(w).Encode(rawAssetPayload(3, map[string]bool{"en-US": false}))
+		}
+	})
+
+	// test server
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	asset := &Asset{Sys: &Sys{ID: "nyancat", Version: 3}}
+
+	// locale without a file
+	err := cma.Assets.ProcessAndWait(context.Background(), spaceID, asset, fastAssetPolling, "fr-FR")
+	assert.NotNil(err)
+	processingErr, ok := err.(AssetProcessingError)
+	assert.True(ok)
+	assert.Equal("fr-FR", processingErr.Locale)
+
+	// processing never completes
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err = cma.Assets.ProcessAndWait(ctx, spaceID, asset, fastAssetPolling)
+	assert.Equal(context.DeadlineExceeded, err)
+}
+
+func TestAssetsServiceProcessAndWaitFailures(t *testing.T) {
+	assert := assert.New(t)
+	gets, fails := 0, true
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			w.WriteHeader(204)
+		case "GET":
+			gets++
+			payload := rawAssetPayload(3, map[string]bool{"en-US": false})
+
+			// failed processing clears the upload without setting a url
+			if fails && gets > 1 {
+				file := payload["fields"].(map[string]interface{})["file"].(map[string]interface{})["en-US"]
+				delete(file.(map[string]interface{}), "upload")
+			}
+
+			json.NewEncoder(w).Encode(payload)
+		}
+	})
+
+	// test server
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	asset := &Asset{Sys: &Sys{ID: "nyancat", Version: 3}}
+
+	err := cma.Assets.ProcessAndWait(context.Background(), spaceID, asset, fastAssetPolling)
+	processingErr, ok := err.(AssetProcessingError)
+	assert.True(ok)
+	assert.Equal("nyancat", processingErr.AssetID)
+	assert.Equal("en-US", processingErr.Locale)
+	assert.Equal(`processing file of asset "nyancat" for locale "en-US" failed: the upload was cleared without a url, the file could not be processed`, err.Error())
+
+	// processing never completes within the polls
+	gets, fails = 0, false
+	err = cma.Assets.ProcessAndWait(context.Background(), spaceID, asset, &AssetProcessingOptions{
+		Interval: time.Millisecond,
+		MaxPolls: 3,
+	})
+	processingErr, ok = err.(AssetProcessingError)
+	assert.True(ok)
+	assert.Equal("nyancat", processingErr.AssetID)
+	assert.Equal("en-US", processingErr.Locale)
+	assert.Equal("file has no url after 3 polls", processingErr.Err.Error())
+	assert.Equal(4, gets)
+}
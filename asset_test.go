@@ -1 +1,192 @@
-package contentful
\ No newline at end of file
+package contentful
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssetUnmarshalAllLocales(t *testing.T) {
+	assert := assert.New(t)
+
+	var asset Asset
+	err := json.Unmarshal([]byte(readTestData("spaces-id1-assets-3HNzx9gvJScKku4UmcekYw.json")), &asset)
+	assert.Nil(err)
+
+	assert.Equal([]string{"de", "en-US"}, asset.Locales())
+	assert.Equal("hehehe", asset.Title("en-US"))
+	assert.Equal("hehehe-de", asset.Title("de"))
+	assert.Equal("asdfasf-de", asset.Description("de"))
+	assert.Equal(6198, asset.File("en-US").Detail.Size)
+	assert.Equal(206, asset.File("de").Detail.Image.Width)
+	assert.Nil(asset.File("fr-FR"))
+}
+
+func TestAssetUnmarshalSingleLocale(t *testing.T) {
+	assert := assert.New(t)
+
+	var asset Asset
+	err := json.Unmarshal([]byte(readTestData("spaces-id1-assets-1x0xpXu4pSGS4OukSyWGUK.json")), &asset)
+	assert.Nil(err)
+
+	assert.Equal([]string{"en-US"}, asset.Locales())
+	assert.Equal("Doge", asset.Title("en-US"))
+	assert.Equal("nice picture", asset.Description("en-US"))
+	assert.Equal("doge.jpg", asset.File("en-US").Name)
+	assert.Equal(5800, asset.File("en-US").Detail.Image.Width)
+}
+
+func TestAssetUnmarshalMissingFields(t *testing.T) {
+	assert := assert.New(t)
+
+	payloads := []string{
+		`{"sys": {"id": "empty"}}`,
+		`{"sys": {"id": "empty"}, "fields": {}}`,
+		`{"sys": {"id": "empty", "locale": "en-US"}, "fields": {"title": "Only a title"}}`,
+		`{"sys": {"id": "empty"}, "fields": {"file": {"en-US": {"upload": "https://www.example.com/cat.png"}}}}`,
+	}
+
+	for _, payload := range payloads {
+		var asset Asset
+		assert.Nil(json.Unmarshal([]byte(payload), &asset), payload)
+		assert.Equal("empty", asset.Sys.ID)
+		assert.Equal("", asset.Description("en-US"))
+	}
+
+	var asset Asset
+	err := json.Unmarshal([]byte(`{"sys": {"id": "invalid"}, "fields": {"title": 42}}`), &asset)
+	assert.NotNil(err)
+}
+
+func TestAssetMarshalRoundTrip(t *testing.T) {
+	assert := assert.New(t)
+
+	asset := &Asset{Sys: &Sys{ID: "nyancat"}}
+	asset.SetTitle("en-US", "Nyan Cat")
+	asset.SetTitle("de-DE", "Nyan Katze")
+	asset.SetDescription("de-DE", "Alternativtext")
+	asset.SetFile("en-US", &File{
+		Name:        "nyancat.png",
+		ContentType: "image/png",
+		UploadURL:   "https://www.example.com/nyancat.png",
+	})
+
+	bytesArray, err := json.Marshal(asset)
+	assert.Nil(err)
+
+	var payload struct {
+		Fields map[string]map[string]interface{} `json:"fields"`
+	}
+	assert.Nil(json.Unmarshal(bytesArray, &payload))
+	assert.Equal("Nyan Katze", payload.Fields["title"]["de-DE"])
+	assert.Nil(payload.Fields["description"]["en-US"])
+
+	var decoded Asset
+	assert.Nil(json.Unmarshal(bytesArray, &decoded))
+	assert.Equal(asset.Fields, decoded.Fields)
+	assert.Equal([]string{"de-DE", "en-US"}, decoded.Locales())
+}
+
+func TestAssetsServiceGet(t *testing.T) {
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("GET", r.Method)
+		assert.Equal("/spaces/"+spaceID+"/assets/3HNzx9gvJScKku4UmcekYw", r.URL.Path)
+
+		fmt.Fprintln(w, readTestData("spaces-id1-assets-3HNzx9gvJScKku4UmcekYw.json"))
+	})
+
+	// test server
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	asset, err := cma.Assets.Get(spaceID, "3HNzx9gvJScKku4UmcekYw")
+	assert.Nil(err)
+	assert.Equal(9, asset.Sys.Version)
+	assert.Equal("hehehe", asset.Title("en-US"))
+}
+
+func TestAssetsServiceUpsert(t *testing.T) {
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("POST", r.Method)
+		assert.Equal("/spaces/"+spaceID+"/assets", r.URL.Path)
+
+		body, err := ioutil.ReadAll(r.Body)
+		assert.Nil(err)
+
+		var payload struct {
+			Fields map[string]map[string]interface{} `json:"fields"`
+		}
+		assert.Nil(json.Unmarshal(body, &payload))
+		assert.Equal("Nyan Cat", payload.Fields["title"]["en-US"])
+		assert.Equal("Nyan Katze", payload.Fields["title"]["de-DE"])
+		assert.NotNil(payload.Fields["file"]["de-DE"])
+
+		fmt.Fprintln(w, string(body))
+	})
+
+	// test server
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	asset := &Asset{Sys: &Sys{}}
+	asset.SetTitle("en-US", "Nyan Cat")
+	asset.SetTitle("de-DE", "Nyan Katze")
+	asset.SetFile("de-DE", &File{Name: "nyan.png", ContentType: "image/png", UploadURL: "https://www.example.com/nyan.png"})
+
+	err := cma.Assets.Upsert(spaceID, asset)
+	assert.Nil(err)
+	assert.Equal("Nyan Katze", asset.Title("de-DE"))
+}
+
+func TestAssetsServiceProcess(t *testing.T) {
+	assert := assert.New(t)
+
+	processed := []string{}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("PUT", r.Method)
+		assert.Equal("9", r.Header.Get("X-Contentful-Version"))
+		processed = append(processed, r.URL.Path)
+		w.WriteHeader(204)
+	})
+
+	// test server
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	asset := &Asset{Sys: &Sys{ID: "nyancat", Version: 9}}
+	asset.SetFile("en-US", &File{UploadURL: "https://www.example.com/en.png"})
+	asset.SetFile("de-DE", &File{UploadURL: "https://www.example.com/de.png"})
+
+	err := cma.Assets.Process(spaceID, asset)
+	assert.Nil(err)
+	assert.Equal([]string{
+		"/spaces/" + spaceID + "/assets/nyancat/files/de-DE/process",
+		"/spaces/" + spaceID + "/assets/nyancat/files/en-US/process",
+	}, processed)
+
+	processed = []string{}
+	err = cma.Assets.Process(spaceID, asset, "en-US")
+	assert.Nil(err)
+	assert.Equal([]string{"/spaces/" + spaceID + "/assets/nyancat/files/en-US/process"}, processed)
+}
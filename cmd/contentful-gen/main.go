@@ -0,0 +1,83 @@
+// Command contentful-gen generates Go structs and constants from contentful content types,
+// read from a space or from an exported json file. It is meant to be run by go generate:
+//
+//	//go:generate contentful-gen -file content_types.json -package models -o models_gen.go
+//	//go:generate contentful-gen -space cfexampleapi -package models -o models_gen.go
+//
+// The management token of -space is read from -token or CONTENTFUL_MANAGEMENT_TOKEN.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	contentful "github.com/contentful-labs/contentful-go"
+	"github.com/contentful-labs/contentful-go/codegen"
+)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintf(os.Stderr, "contentful-gen: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	file := flag.String("file", "", "read the content types from an exported json file")
+	space := flag.String("space", "", "read the content types of a space")
+	token := flag.String("token", os.Getenv("CONTENTFUL_MANAGEMENT_TOKEN"), "management api token, for -space")
+	packageName := flag.String("package", "", "name of the generated package, defaults to $GOPACKAGE or models")
+	output := flag.String("o", "", "write the code to a file instead of stdout")
+	flag.Parse()
+
+	if *packageName == "" {
+		*packageName = os.Getenv("GOPACKAGE")
+	}
+
+	var contentTypes []*contentful.ContentType
+	var err error
+
+	switch {
+	case *file != "" && *space != "":
+		return fmt.Errorf("-file and -space can not be used together")
+	case *file != "":
+		f, err := os.Open(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		contentTypes, err = codegen.ReadContentTypes(f)
+		if err != nil {
+			return fmt.Errorf("reading %s: %s", *file, err)
+		}
+	case *space != "":
+		if *token == "" {
+			return fmt.Errorf("-space requires -token or CONTENTFUL_MANAGEMENT_TOKEN")
+		}
+
+		client := contentful.NewCMA(*token)
+
+		contentTypes, err = codegen.FetchContentTypes(client, *space)
+		if err != nil {
+			return fmt.Errorf("fetching the content types of %s: %s", *space, err)
+		}
+	default:
+		flag.Usage()
+		return fmt.Errorf("-file or -space is required")
+	}
+
+	source, err := codegen.Generate(contentTypes, codegen.Options{Package: *packageName})
+	if err != nil {
+		return err
+	}
+
+	if *output == "" {
+		_, err = os.Stdout.Write(source)
+		return err
+	}
+
+	return ioutil.WriteFile(*output, source, 0644)
+}
@@ -0,0 +1,461 @@
+// Package codegen generates Go structs and constants from contentful content types.
+//
+// For every content type it emits the content type id, the field ids, QueryField constants
+// for typed queries, a query helper, enum constants of the predefined values of fields and a
+// struct decoding entries with contentful.Entry.Decode. Link fields restricted to a single
+// generated content type use the link type of that content type.
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	contentful "github.com/contentful-labs/contentful-go"
+)
+
+// Options of the generated code
+type Options struct {
+	// Package is the name of the generated package, it defaults to models
+	Package string
+}
+
+// ReadContentTypes reads content types from json, either an export of a space with a
+// contentTypes list, a collection response with items, or a plain list of content types.
+func ReadContentTypes(r io.Reader) ([]*contentful.ContentType, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		contentTypes := []*contentful.ContentType{}
+		if err := json.Unmarshal(data, &contentTypes); err != nil {
+			return nil, err
+		}
+
+		return contentTypes, nil
+	}
+
+	var payload struct {
+		ContentTypes []*contentful.ContentType `json:"contentTypes"`
+		Items        []*contentful.ContentType `json:"items"`
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, err
+	}
+
+	if payload.ContentTypes != nil {
+		return payload.ContentTypes, nil
+	}
+
+	if payload.Items != nil {
+		return payload.Items, nil
+	}
+
+	return nil, fmt.Errorf("no contentTypes or items found")
+}
+
+// FetchContentTypes lists every content type of the space
+func FetchContentTypes(client *contentful.Client, spaceID string) ([]*contentful.ContentType, error) {
+	col := client.ContentTypes.List(spaceID)
+	if col == nil {
+		return nil, fmt.Errorf("can not list content types of space %s", spaceID)
+	}
+
+	contentTypes := []*contentful.ContentType{}
+	for {
+		if _, err := col.Next(); err != nil {
+			return nil, err
+		}
+
+		contentTypes = append(contentTypes, col.ToContentType()...)
+
+		if len(col.Items) == 0 || col.Skip+len(col.Items) >= col.Total {
+			return contentTypes, nil
+		}
+	}
+}
+
+// initialisms are written in upper case in identifiers, following the go conventions
+var initialisms = map[string]bool{
+	"api": true, "html": true, "http": true, "id": true, "ip": true, "json": true,
+	"seo": true, "sku": true, "uri": true, "url": true, "uuid": true, "xml": true,
+}
+
+// identifier returns the exported go identifier of a name, e.g. blog-post and Blog Post are BlogPost
+func identifier(name string) string {
+	words := []string{}
+	word := []rune{}
+	runes := []rune(name)
+
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, string(word))
+			word = []rune{}
+		}
+	}
+
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+
+		// camel case starts a new word, e.g. authorId
+		if unicode.IsUpper(r) && i > 0 && unicode.IsLower(runes[i-1]) {
+			flush()
+		}
+
+		word = append(word, r)
+	}
+	flush()
+
+	var b strings.Builder
+	for _, word := range words {
+		if initialisms[strings.ToLower(word)] {
+			b.WriteString(strings.ToUpper(word))
+			continue
+		}
+
+		first := []rune(word)
+		b.WriteString(strings.ToUpper(string(first[0])) + string(first[1:]))
+	}
+
+	id := b.String()
+	if id == "" || unicode.IsDigit([]rune(id)[0]) {
+		id = "X" + id
+	}
+
+	return id
+}
+
+// names hands out unique identifiers
+type names map[string]bool
+
+func (n names) unique(name string) string {
+	candidate := name
+	for i := 2; n[candidate]; i++ {
+		candidate = name + strconv.Itoa(i)
+	}
+	n[candidate] = true
+
+	return candidate
+}
+
+type generator struct {
+	buf   bytes.Buffer
+	names names
+	// typeNames and linkNames are the struct and link type names of the content types by id
+	typeNames map[string]string
+	linkNames map[string]string
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// Generate returns the formatted go source of the content types
+func Generate(contentTypes []*contentful.ContentType, options Options) ([]byte, error) {
+	packageName := options.Package
+	if packageName == "" {
+		packageName = "models"
+	}
+
+	if len(contentTypes) == 0 {
+		return nil, fmt.Errorf("no content types to generate")
+	}
+
+	sorted := []*contentful.ContentType{}
+	for _, ct := range contentTypes {
+		if ct == nil || ct.Sys == nil || ct.Sys.ID == "" {
+			return nil, fmt.Errorf("content types require a sys.id")
+		}
+		sorted = append(sorted, ct)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Sys.ID < sorted[j].Sys.ID })
+
+	g := &generator{names: names{}, typeNames: map[string]string{}, linkNames: map[string]string{}}
+	for _, ct := range sorted {
+		name := ct.Name
+		if name == "" {
+			name = ct.Sys.ID
+		}
+
+		g.typeNames[ct.Sys.ID] = g.names.unique(identifier(name))
+	}
+
+	for _, ct := range sorted {
+		g.linkNames[ct.Sys.ID] = g.names.unique(g.typeNames[ct.Sys.ID] + "Link")
+	}
+
+	g.printf("// Code generated by contentful-gen. DO NOT EDIT.\n\n")
+	g.printf("package %s\n\n", packageName)
+	g.printf("import contentful %q\n\n", "github.com/contentful-labs/contentful-go")
+
+	for _, ct := range sorted {
+		g.contentType(ct)
+	}
+
+	source, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %s", err)
+	}
+
+	return source, nil
+}
+
+// fields returns the fields of the content type delivered by the apis
+func fields(ct *contentful.ContentType) []*contentful.Field {
+	list := []*contentful.Field{}
+	for _, field := range ct.Fields {
+		if field != nil && field.ID != "" && !field.Omitted {
+			list = append(list, field)
+		}
+	}
+
+	return list
+}
+
+func (g *generator) contentType(ct *contentful.ContentType) {
+	typeName := g.typeNames[ct.Sys.ID]
+	title := ct.Name
+	if title == "" {
+		title = ct.Sys.ID
+	}
+
+	fieldNames := map[string]string{}
+	structNames := names{"Sys": true}
+	for _, field := range fields(ct) {
+		fieldNames[field.ID] = structNames.unique(identifier(field.ID))
+	}
+
+	idName := g.names.unique(typeName + "ContentTypeID")
+	g.printf("\n// %s is the id of the %s content type\n", idName, title)
+	g.printf("const %s = %q\n", idName, ct.Sys.ID)
+
+	if len(fields(ct)) > 0 {
+		g.printf("\n// Field ids of the %s content type\n", title)
+		g.printf("const (\n")
+		for _, field := range fields(ct) {
+			g.printf("%s = %q\n", g.names.unique(typeName+"Field"+fieldNames[field.ID]), field.ID)
+		}
+		g.printf(")\n")
+
+		g.printf("\n// Query fields of the %s content type\n", title)
+		g.printf("const (\n")
+		for _, field := range fields(ct) {
+			g.printf("%s contentful.QueryField = %q\n", g.names.unique(typeName+"Query"+fieldNames[field.ID]), "fields."+field.ID)
+		}
+		g.printf(")\n")
+	}
+
+	queryName := g.names.unique(typeName + "Query")
+	g.printf("\n// %s returns a query for entries of the %s content type\n", queryName, title)
+	g.printf("func %s() *contentful.Query {\n", queryName)
+	g.printf("return contentful.NewQuery().ContentType(%s)\n", idName)
+	g.printf("}\n")
+
+	enums := map[string]string{}
+	for _, field := range fields(ct) {
+		if enum := g.enum(typeName, title, fieldNames[field.ID], field); enum != "" {
+			enums[field.ID] = enum
+		}
+	}
+
+	linkName := g.linkNames[ct.Sys.ID]
+	g.printf("\n// %s is a link to an entry of the %s content type\n", linkName, title)
+	g.printf("type %s contentful.Link\n", linkName)
+
+	if ct.Description != "" {
+		g.printf("\n// %s model of the %s content type: %s\n", typeName, title, strings.Join(strings.Fields(ct.Description), " "))
+	} else {
+		g.printf("\n// %s model of the %s content type\n", typeName, title)
+	}
+	g.printf("type %s struct {\n", typeName)
+	g.printf("Sys *contentful.Sys `json:\"sys\"`\n")
+	for _, field := range fields(ct) {
+		goType, omitEmpty := g.goType(field, enums[field.ID])
+		tag := field.ID
+		if omitEmpty {
+			tag += ",omitempty"
+		}
+
+		if field.Name != "" && identifier(field.Name) != fieldNames[field.ID] {
+			g.printf("// %s\n", strings.Join(strings.Fields(field.Name), " "))
+		}
+		g.printf("%s %s `json:%q`\n", fieldNames[field.ID], goType, tag)
+	}
+	g.printf("}\n")
+
+	fromEntry := g.names.unique(typeName + "FromEntry")
+	g.printf("\n// %s decodes the values of the locale of an entry of the %s content type\n", fromEntry, title)
+	g.printf("func %s(entry *contentful.Entry, locale string) (*%s, error) {\n", fromEntry, typeName)
+	g.printf("v := &%s{}\n", typeName)
+	g.printf("if err := entry.Decode(locale, v); err != nil {\nreturn nil, err\n}\n\n")
+	g.printf("return v, nil\n")
+	g.printf("}\n")
+}
+
+// predefinedValues returns the values of the in validation of the field or of its items
+func predefinedValues(field *contentful.Field) []interface{} {
+	validations := field.Validations
+	if field.Type == contentful.FieldTypeArray && field.Items != nil {
+		validations = field.Items.Validations
+	}
+
+	for _, validation := range validations {
+		switch validation := validation.(type) {
+		case contentful.FieldValidationPredefinedValues:
+			return validation.In
+		case *contentful.FieldValidationPredefinedValues:
+			return validation.In
+		}
+	}
+
+	return nil
+}
+
+// enum declares the type and constants of the predefined values of the field,
+// it returns the name of the type or an empty string for fields without predefined values
+func (g *generator) enum(typeName, title, fieldName string, field *contentful.Field) string {
+	values := predefinedValues(field)
+	if len(values) == 0 {
+		return ""
+	}
+
+	valueType := field.Type
+	if field.Type == contentful.FieldTypeArray && field.Items != nil {
+		valueType = field.Items.Type
+	}
+
+	var baseType string
+	switch valueType {
+	case contentful.FieldTypeSymbol, contentful.FieldTypeText:
+		baseType = "string"
+	case contentful.FieldTypeInteger:
+		baseType = "int"
+	case contentful.FieldTypeNumber:
+		baseType = "float64"
+	default:
+		return ""
+	}
+
+	enumName := g.names.unique(typeName + fieldName)
+	g.printf("\n// %s are the predefined values of the %s field of the %s content type\n", enumName, field.ID, title)
+	g.printf("type %s %s\n\n", enumName, baseType)
+	g.printf("// Predefined values of %s\n", enumName)
+	g.printf("const (\n")
+
+	for _, value := range values {
+		var literal, name string
+		switch value := value.(type) {
+		case string:
+			if baseType != "string" {
+				continue
+			}
+			literal, name = strconv.Quote(value), identifier(value)
+			if value == "" {
+				name = "Empty"
+			}
+		case float64:
+			if baseType == "string" || (baseType == "int" && value != float64(int64(value))) {
+				continue
+			}
+			literal = strconv.FormatFloat(value, 'f', -1, 64)
+			name = identifier(strings.NewReplacer("-", "Minus", ".", "Point").Replace(literal))
+		default:
+			continue
+		}
+
+		g.printf("%s %s = %s\n", g.names.unique(enumName+name), enumName, literal)
+	}
+
+	g.printf(")\n")
+
+	return enumName
+}
+
+// linkType returns the go type of a link field
+func (g *generator) linkType(linkType string, validations []contentful.FieldValidation) string {
+	if linkType != "Entry" {
+		return "*contentful.Link"
+	}
+
+	for _, validation := range validations {
+		var contentTypes []string
+		switch validation := validation.(type) {
+		case contentful.FieldValidationLink:
+			contentTypes = validation.LinkContentType
+		case *contentful.FieldValidationLink:
+			contentTypes = validation.LinkContentType
+		default:
+			continue
+		}
+
+		if len(contentTypes) == 1 {
+			if linkName, ok := g.linkNames[contentTypes[0]]; ok {
+				return "*" + linkName
+			}
+		}
+	}
+
+	return "*contentful.Link"
+}
+
+// goType returns the go type of the field and whether empty values are left out of its json
+func (g *generator) goType(field *contentful.Field, enum string) (string, bool) {
+	switch field.Type {
+	case contentful.FieldTypeSymbol, contentful.FieldTypeText:
+		if enum != "" {
+			return enum, true
+		}
+		return "string", true
+	case contentful.FieldTypeInteger:
+		if enum != "" {
+			return enum, false
+		}
+		return "int", false
+	case contentful.FieldTypeNumber:
+		if enum != "" {
+			return enum, false
+		}
+		return "float64", false
+	case contentful.FieldTypeBoolean:
+		return "bool", false
+	case contentful.FieldTypeDate:
+		return "string", true
+	case contentful.FieldTypeLocation:
+		return "*contentful.Location", true
+	case contentful.FieldTypeRichText:
+		return "*contentful.RichTextNode", true
+	case contentful.FieldTypeLink:
+		return g.linkType(field.LinkType, field.Validations), true
+	case contentful.FieldTypeArray:
+		if field.Items == nil {
+			return "[]interface{}", true
+		}
+
+		switch field.Items.Type {
+		case contentful.FieldTypeSymbol:
+			if enum != "" {
+				return "[]" + enum, true
+			}
+			return "[]string", true
+		case contentful.FieldTypeLink:
+			return "[]" + g.linkType(field.Items.LinkType, field.Items.Validations), true
+		}
+
+		return "[]interface{}", true
+	}
+
+	return "map[string]interface{}", true
+}
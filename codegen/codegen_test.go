@@ -0,0 +1,214 @@
+package codegen
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	contentful "github.com/contentful-labs/contentful-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func readTestData(fileName string) string {
+	content, err := ioutil.ReadFile("../testdata/" + fileName)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return string(content)
+}
+
+func contentTypesFromTestData() []*contentful.ContentType {
+	contentTypes, err := ReadContentTypes(strings.NewReader(readTestData("content_types.json")))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return contentTypes
+}
+
+// postContentType links to the cats of the test data and has predefined values
+func postContentType() *contentful.ContentType {
+	return &contentful.ContentType{
+		Sys:         &contentful.Sys{ID: "blogPost"},
+		Name:        "Blog Post",
+		Description: "A post of the blog",
+		Fields: []*contentful.Field{
+			{ID: "title", Name: "Title", Type: contentful.FieldTypeSymbol},
+			{ID: "category", Name: "Category", Type: contentful.FieldTypeSymbol, Validations: []contentful.FieldValidation{
+				contentful.FieldValidationPredefinedValues{In: []interface{}{"news", "How-To", ""}},
+			}},
+			{ID: "rating", Name: "Rating", Type: contentful.FieldTypeNumber, Validations: []contentful.FieldValidation{
+				contentful.FieldValidationPredefinedValues{In: []interface{}{1.5, -2.0}},
+			}},
+			{ID: "tags", Name: "Tags", Type: contentful.FieldTypeArray, Items: &contentful.FieldTypeArrayItem{
+				Type: contentful.FieldTypeSymbol,
+				Validations: []contentful.FieldValidation{
+					contentful.FieldValidationPredefinedValues{In: []interface{}{"go", "cats"}},
+				},
+			}},
+			{ID: "cat", Name: "Cat", Type: contentful.FieldTypeLink, LinkType: "Entry", Validations: []contentful.FieldValidation{
+				contentful.FieldValidationLink{LinkContentType: []string{"cat"}},
+			}},
+			{ID: "relatedCats", Name: "Related cats", Type: contentful.FieldTypeArray, Items: &contentful.FieldTypeArrayItem{
+				Type:     contentful.FieldTypeLink,
+				LinkType: "Entry",
+				Validations: []contentful.FieldValidation{
+					contentful.FieldValidationLink{LinkContentType: []string{"cat"}},
+				},
+			}},
+			{ID: "anything", Name: "Anything", Type: contentful.FieldTypeLink, LinkType: "Entry"},
+			{ID: "authorId", Name: "Author", Type: contentful.FieldTypeInteger},
+			{ID: "body", Name: "Body", Type: contentful.FieldTypeRichText},
+			{ID: "hidden", Name: "Hidden", Type: contentful.FieldTypeSymbol, Omitted: true},
+		},
+	}
+}
+
+func TestIdentifier(t *testing.T) {
+	assert := assert.New(t)
+
+	for name, expected := range map[string]string{
+		"blogPost":     "BlogPost",
+		"Blog Post":    "BlogPost",
+		"blog-post":    "BlogPost",
+		"authorId":     "AuthorID",
+		"image_url":    "ImageURL",
+		"2PqfXUJwE8qS": "X2PqfXUJwE8qS",
+		"How-To":       "HowTo",
+		"---":          "X",
+		"ärger":        "Ärger",
+	} {
+		assert.Equal(expected, identifier(name), name)
+	}
+}
+
+func TestReadContentTypes(t *testing.T) {
+	assert := assert.New(t)
+
+	// collection responses
+	contentTypes := contentTypesFromTestData()
+	assert.Equal(4, len(contentTypes))
+	assert.Equal("City", contentTypes[0].Name)
+
+	// space exports
+	contentTypes, err := ReadContentTypes(strings.NewReader(`{"contentTypes": [{"sys": {"id": "cat"}, "name": "Cat"}], "entries": []}`))
+	assert.Nil(err)
+	assert.Equal(1, len(contentTypes))
+	assert.Equal("cat", contentTypes[0].Sys.ID)
+
+	// lists
+	contentTypes, err = ReadContentTypes(strings.NewReader(`[{"sys": {"id": "cat"}}, {"sys": {"id": "dog"}}]`))
+	assert.Nil(err)
+	assert.Equal(2, len(contentTypes))
+
+	_, err = ReadContentTypes(strings.NewReader(`{"sys": {"id": "cat"}}`))
+	assert.EqualError(err, "no contentTypes or items found")
+}
+
+func TestFetchContentTypes(t *testing.T) {
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("/spaces/id1/content_types", r.URL.Path)
+		fmt.Fprintln(w, readTestData("content_types.json"))
+	})
+
+	// test server
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// cma client
+	cma := contentful.NewCMA("token")
+	cma.BaseURL = server.URL
+
+	contentTypes, err := FetchContentTypes(cma, "id1")
+	assert.Nil(err)
+	assert.Equal(4, len(contentTypes))
+}
+
+func TestGenerate(t *testing.T) {
+	assert := assert.New(t)
+
+	contentTypes := append(contentTypesFromTestData(), postContentType())
+	source, err := Generate(contentTypes, Options{Package: "blog"})
+	assert.Nil(err)
+
+	_, err = parser.ParseFile(token.NewFileSet(), "blog.go", source, parser.AllErrors)
+	assert.Nil(err)
+
+	// alignment of gofmt is left out of the comparisons
+	code := strings.Join(strings.Fields(string(source)), " ")
+	for _, expected := range []string{
+		"// Code generated by contentful-gen. DO NOT EDIT.",
+		"package blog",
+		`const BlogPostContentTypeID = "blogPost"`,
+		`BlogPostFieldTitle = "title"`,
+		`BlogPostQueryTitle contentful.QueryField = "fields.title"`,
+		"return contentful.NewQuery().ContentType(BlogPostContentTypeID)",
+		"type BlogPostCategory string",
+		`BlogPostCategoryNews BlogPostCategory = "news"`,
+		`BlogPostCategoryHowTo BlogPostCategory = "How-To"`,
+		`BlogPostCategoryEmpty BlogPostCategory = ""`,
+		"type BlogPostRating float64",
+		"BlogPostRatingX1Point5 BlogPostRating = 1.5",
+		"BlogPostRatingMinus2 BlogPostRating = -2",
+		"type CatLink contentful.Link",
+		"// BlogPost model of the Blog Post content type: A post of the blog",
+		"Category BlogPostCategory `json:\"category,omitempty\"`",
+		"Rating BlogPostRating `json:\"rating\"`",
+		"Tags []BlogPostTags `json:\"tags,omitempty\"`",
+		"Cat *CatLink `json:\"cat,omitempty\"`",
+		"// Author AuthorID int",
+		"RelatedCats []*CatLink `json:\"relatedCats,omitempty\"`",
+		"Anything *contentful.Link `json:\"anything,omitempty\"`",
+		"AuthorID int `json:\"authorId\"`",
+		"Body *contentful.RichTextNode `json:\"body,omitempty\"`",
+		"func BlogPostFromEntry(entry *contentful.Entry, locale string) (*BlogPost, error) {",
+		"Center *contentful.Location `json:\"center,omitempty\"`",
+	} {
+		assert.Contains(code, expected)
+	}
+
+	// omitted fields are not delivered
+	assert.NotContains(code, "hidden")
+
+	// the output does not depend on the order of the content types
+	reversed := []*contentful.ContentType{}
+	for i := len(contentTypes) - 1; i >= 0; i-- {
+		reversed = append(reversed, contentTypes[i])
+	}
+	again, err := Generate(reversed, Options{Package: "blog"})
+	assert.Nil(err)
+	assert.Equal(string(source), string(again))
+}
+
+func TestGenerateUniqueNames(t *testing.T) {
+	assert := assert.New(t)
+
+	source, err := Generate([]*contentful.ContentType{
+		{Sys: &contentful.Sys{ID: "cat"}, Name: "Cat"},
+		{Sys: &contentful.Sys{ID: "cat2"}, Name: "cat"},
+		{Sys: &contentful.Sys{ID: "catLink"}, Name: "Cat Link"},
+	}, Options{})
+	assert.Nil(err)
+
+	code := string(source)
+	assert.Contains(code, "package models")
+	assert.Contains(code, "type Cat struct")
+	assert.Contains(code, "type Cat2 struct")
+	assert.Contains(code, "type CatLink struct")
+	assert.Contains(code, "type CatLink2 contentful.Link")
+
+	_, err = Generate(nil, Options{})
+	assert.EqualError(err, "no content types to generate")
+
+	_, err = Generate([]*contentful.ContentType{{Name: "Cat"}}, Options{})
+	assert.EqualError(err, "content types require a sys.id")
+}
@@ -43,14 +43,18 @@ func NewCollection(options *CollectionOptions) *Collection {
 // Next makes the col.req
 func (col *Collection) Next() (*Collection, error) {
 	// setup query params
-	skip := uint16(col.Limit) * (col.page - 1)
-	col.Query.Skip(skip)
+	// the first page is requested without a skip parameter
+	col.Query.skip = uint16(col.Limit) * (col.page - 1)
 
 	// override request query
-	col.req.URL.RawQuery = col.Query.String()
+	params, err := col.Query.Build()
+	if err != nil {
+		return nil, err
+	}
+	col.req.URL.RawQuery = params.Encode()
 
 	// makes api call
-	err := col.c.do(col.req, col)
+	err = col.c.do(col.req, col)
 	if err != nil {
 		return nil, err
 	}
@@ -129,3 +133,63 @@ func (col *Collection) ToWebhook() []*Webhook {
 
 	return webhooks
 }
+
+// ToRelease cast Items to Release model
+func (col *Collection) ToRelease() []*Release {
+	var releases []*Release
+
+	byteArray, _ := json.Marshal(col.Items)
+	json.NewDecoder(bytes.NewReader(byteArray)).Decode(&releases)
+
+	return releases
+}
+
+// ToReleaseAction cast Items to ReleaseAction model
+func (col *Collection) ToReleaseAction() []*ReleaseAction {
+	var actions []*ReleaseAction
+
+	byteArray, _ := json.Marshal(col.Items)
+	json.NewDecoder(bytes.NewReader(byteArray)).Decode(&actions)
+
+	return actions
+}
+
+// ToScheduledAction cast Items to ScheduledAction model
+func (col *Collection) ToScheduledAction() []*ScheduledAction {
+	var actions []*ScheduledAction
+
+	byteArray, _ := json.Marshal(col.Items)
+	json.NewDecoder(bytes.NewReader(byteArray)).Decode(&actions)
+
+	return actions
+}
+
+// ToEntrySnapshot cast Items to EntrySnapshot model
+func (col *Collection) ToEntrySnapshot() []*EntrySnapshot {
+	var snapshots []*EntrySnapshot
+
+	byteArray, _ := json.Marshal(col.Items)
+	json.NewDecoder(bytes.NewReader(byteArray)).Decode(&snapshots)
+
+	return snapshots
+}
+
+// ToContentTypeSnapshot cast Items to ContentTypeSnapshot model
+func (col *Collection) ToContentTypeSnapshot() []*ContentTypeSnapshot {
+	var snapshots []*ContentTypeSnapshot
+
+	byteArray, _ := json.Marshal(col.Items)
+	json.NewDecoder(bytes.NewReader(byteArray)).Decode(&snapshots)
+
+	return snapshots
+}
+
+// ToTag cast Items to Tag model
+func (col *Collection) ToTag() []*Tag {
+	var tags []*Tag
+
+	byteArray, _ := json.Marshal(col.Items)
+	json.NewDecoder(bytes.NewReader(byteArray)).Decode(&tags)
+
+	return tags
+}
@@ -46,6 +46,12 @@ const (
 
 	// FieldTypeObject content type field type for object data
 	FieldTypeObject = "Object"
+
+	// FieldTypeNumber content type field type for decimal number data
+	FieldTypeNumber = "Number"
+
+	// FieldTypeRichText content type field type for rich text data
+	FieldTypeRichText = "RichText"
 )
 
 // Field model
@@ -127,7 +133,8 @@ func (field *Field) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-// ParseValidations converts json representation to go struct
+// ParseValidations converts json representation to go struct.
+// Validations without a model are kept as FieldValidationUnknown.
 func ParseValidations(data []interface{}) (validations []FieldValidation, err error) {
 	for _, value := range data {
 		var validation map[string]interface{}
@@ -150,6 +157,10 @@ func ParseValidations(data []interface{}) (validations []FieldValidation, err er
 			validation = validationMap
 		}
 
+		if byteArray == nil {
+			continue
+		}
+
 		if _, ok := validation["linkContentType"]; ok {
 			var fieldValidationLink FieldValidationLink
 			if err := json.Unmarshal(byteArray, &fieldValidationLink); err != nil {
@@ -157,6 +168,8 @@ func ParseValidations(data []interface{}) (validations []FieldValidation, err er
 			}
 
 			validations = append(validations, fieldValidationLink)
+
+			continue
 		}
 
 		if _, ok := validation["linkMimetypeGroup"]; ok {
@@ -166,6 +179,8 @@ func ParseValidations(data []interface{}) (validations []FieldValidation, err er
 			}
 
 			validations = append(validations, fieldValidationMimeType)
+
+			continue
 		}
 
 		if _, ok := validation["assetImageDimensions"]; ok {
@@ -175,6 +190,8 @@ func ParseValidations(data []interface{}) (validations []FieldValidation, err er
 			}
 
 			validations = append(validations, fieldValidationDimension)
+
+			continue
 		}
 
 		if _, ok := validation["assetFileSize"]; ok {
@@ -184,6 +201,8 @@ func ParseValidations(data []interface{}) (validations []FieldValidation, err er
 			}
 
 			validations = append(validations, fieldValidationFileSize)
+
+			continue
 		}
 
 		if _, ok := validation["unique"]; ok {
@@ -193,6 +212,8 @@ func ParseValidations(data []interface{}) (validations []FieldValidation, err er
 			}
 
 			validations = append(validations, fieldValidationUnique)
+
+			continue
 		}
 
 		if _, ok := validation["in"]; ok {
@@ -202,6 +223,8 @@ func ParseValidations(data []interface{}) (validations []FieldValidation, err er
 			}
 
 			validations = append(validations, fieldValidationPredefinedValues)
+
+			continue
 		}
 
 		if _, ok := validation["range"]; ok {
@@ -211,6 +234,8 @@ func ParseValidations(data []interface{}) (validations []FieldValidation, err er
 			}
 
 			validations = append(validations, fieldValidationRange)
+
+			continue
 		}
 
 		if _, ok := validation["dateRange"]; ok {
@@ -220,6 +245,8 @@ func ParseValidations(data []interface{}) (validations []FieldValidation, err er
 			}
 
 			validations = append(validations, fieldValidationDate)
+
+			continue
 		}
 
 		if _, ok := validation["size"]; ok {
@@ -229,6 +256,8 @@ func ParseValidations(data []interface{}) (validations []FieldValidation, err er
 			}
 
 			validations = append(validations, fieldValidationSize)
+
+			continue
 		}
 
 		if _, ok := validation["regexp"]; ok {
@@ -238,7 +267,56 @@ func ParseValidations(data []interface{}) (validations []FieldValidation, err er
 			}
 
 			validations = append(validations, fieldValidationRegex)
+
+			continue
+		}
+
+		if _, ok := validation["prohibitRegexp"]; ok {
+			var fieldValidationProhibitRegex FieldValidationProhibitRegex
+			if err := json.Unmarshal(byteArray, &fieldValidationProhibitRegex); err != nil {
+				return nil, err
+			}
+
+			validations = append(validations, fieldValidationProhibitRegex)
+
+			continue
 		}
+
+		if _, ok := validation["enabledNodeTypes"]; ok {
+			var fieldValidationEnabledNodeTypes FieldValidationEnabledNodeTypes
+			if err := json.Unmarshal(byteArray, &fieldValidationEnabledNodeTypes); err != nil {
+				return nil, err
+			}
+
+			validations = append(validations, fieldValidationEnabledNodeTypes)
+
+			continue
+		}
+
+		if _, ok := validation["enabledMarks"]; ok {
+			var fieldValidationEnabledMarks FieldValidationEnabledMarks
+			if err := json.Unmarshal(byteArray, &fieldValidationEnabledMarks); err != nil {
+				return nil, err
+			}
+
+			validations = append(validations, fieldValidationEnabledMarks)
+
+			continue
+		}
+
+		if _, ok := validation["nodes"]; ok {
+			var fieldValidationNodes FieldValidationNodes
+			if err := json.Unmarshal(byteArray, &fieldValidationNodes); err != nil {
+				return nil, err
+			}
+
+			validations = append(validations, fieldValidationNodes)
+
+			continue
+		}
+
+		// keep validations unknown to the sdk as they are
+		validations = append(validations, FieldValidationUnknown{Raw: byteArray})
 	}
 
 	return validations, nil
@@ -288,6 +366,17 @@ func (ct *ContentType) GetVersion() int {
 	return version
 }
 
+// Field returns the field definition with the given id, nil if there is none
+func (ct *ContentType) Field(fieldID string) *Field {
+	for _, field := range ct.Fields {
+		if field.ID == fieldID {
+			return field
+		}
+	}
+
+	return nil
+}
+
 // List return a content type collection
 func (service *ContentTypesService) List(spaceID string) *Collection {
 	path := fmt.Sprintf("/spaces/%s/content_types", spaceID)
@@ -348,6 +437,8 @@ func (service *ContentTypesService) Upsert(spaceID string, ct *ContentType) erro
 
 	req.Header.Set("X-Contentful-Version", strconv.Itoa(ct.GetVersion()))
 
+	defer service.invalidate(spaceID, ct)
+
 	return service.c.do(req, ct)
 }
 
@@ -364,6 +455,8 @@ func (service *ContentTypesService) Delete(spaceID string, ct *ContentType) erro
 	version := strconv.Itoa(ct.Sys.Version)
 	req.Header.Set("X-Contentful-Version", version)
 
+	defer service.invalidate(spaceID, ct)
+
 	return service.c.do(req, nil)
 }
 
@@ -380,6 +473,8 @@ func (service *ContentTypesService) Activate(spaceID string, ct *ContentType) er
 	version := strconv.Itoa(ct.Sys.Version)
 	req.Header.Set("X-Contentful-Version", version)
 
+	defer service.invalidate(spaceID, ct)
+
 	return service.c.do(req, ct)
 }
 
@@ -396,5 +491,7 @@ func (service *ContentTypesService) Deactivate(spaceID string, ct *ContentType)
 	version := strconv.Itoa(ct.Sys.Version)
 	req.Header.Set("X-Contentful-Version", version)
 
+	defer service.invalidate(spaceID, ct)
+
 	return service.c.do(req, ct)
 }
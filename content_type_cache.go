@@ -0,0 +1,129 @@
+package contentful
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultContentTypeCacheTTL is the lifetime of cached content types of new clients
+const DefaultContentTypeCacheTTL = 5 * time.Minute
+
+// ContentTypeCache keeps content type definitions for schema aware helpers.
+// Entries are populated lazily and expire after the TTL, a TTL of zero never expires them.
+type ContentTypeCache struct {
+	TTL time.Duration
+
+	mu    sync.RWMutex
+	items map[contentTypeCacheKey]*contentTypeCacheItem
+	now   func() time.Time
+}
+
+type contentTypeCacheKey struct {
+	spaceID       string
+	environment   string
+	contentTypeID string
+}
+
+type contentTypeCacheItem struct {
+	ct       *ContentType
+	cachedAt time.Time
+}
+
+// NewContentTypeCache returns an empty cache
+func NewContentTypeCache(ttl time.Duration) *ContentTypeCache {
+	return &ContentTypeCache{
+		TTL:   ttl,
+		items: map[contentTypeCacheKey]*contentTypeCacheItem{},
+		now:   time.Now,
+	}
+}
+
+func (cache *ContentTypeCache) get(key contentTypeCacheKey) (*ContentType, bool) {
+	cache.mu.RLock()
+	defer cache.mu.RUnlock()
+
+	item, ok := cache.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	if cache.TTL > 0 && cache.now().Sub(item.cachedAt) >= cache.TTL {
+		return nil, false
+	}
+
+	return item.ct, true
+}
+
+func (cache *ContentTypeCache) set(key contentTypeCacheKey, ct *ContentType) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	cache.items[key] = &contentTypeCacheItem{ct: ct, cachedAt: cache.now()}
+}
+
+// Invalidate drops the content type from the cache, in every environment of the space
+func (cache *ContentTypeCache) Invalidate(spaceID, contentTypeID string) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	for key := range cache.items {
+		if key.spaceID == spaceID && key.contentTypeID == contentTypeID {
+			delete(cache.items, key)
+		}
+	}
+}
+
+// InvalidateSpace drops every content type of the space from the cache
+func (cache *ContentTypeCache) InvalidateSpace(spaceID string) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	for key := range cache.items {
+		if key.spaceID == spaceID {
+			delete(cache.items, key)
+		}
+	}
+}
+
+// Clear empties the cache
+func (cache *ContentTypeCache) Clear() {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	cache.items = map[contentTypeCacheKey]*contentTypeCacheItem{}
+}
+
+// GetCached returns the content type from the client cache, it is fetched with Get
+// when it is not cached yet or has expired. The returned content type is shared,
+// it must not be modified. Without a cache on the client it is always fetched.
+func (service *ContentTypesService) GetCached(spaceID, contentTypeID string) (*ContentType, error) {
+	cache := service.c.ContentTypeCache
+	if cache == nil {
+		return service.Get(spaceID, contentTypeID)
+	}
+
+	key := contentTypeCacheKey{
+		spaceID:       spaceID,
+		environment:   service.c.Environment,
+		contentTypeID: contentTypeID,
+	}
+
+	if ct, ok := cache.get(key); ok {
+		return ct, nil
+	}
+
+	ct, err := service.Get(spaceID, contentTypeID)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.set(key, ct)
+
+	return ct, nil
+}
+
+func (service *ContentTypesService) invalidate(spaceID string, ct *ContentType) {
+	if service.c.ContentTypeCache != nil && ct.Sys != nil {
+		service.c.ContentTypeCache.Invalidate(spaceID, ct.Sys.ID)
+	}
+}
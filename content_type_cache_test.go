@@ -0,0 +1,147 @@
+package contentful
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func contentTypeCacheServer(requests *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			*requests++
+			fmt.Fprintln(w, `{"sys": {"id": "cat", "version": 1}, "fields": [{"id": "lives", "name": "Lives", "type": "Integer"}]}`)
+			return
+		}
+
+		fmt.Fprintln(w, `{"sys": {"id": "cat", "version": 2}}`)
+	}))
+}
+
+func TestContentTypesServiceGetCached(t *testing.T) {
+	assert := assert.New(t)
+
+	requests := 0
+	server := contentTypeCacheServer(&requests)
+	defer server.Close()
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	now := time.Now()
+	cma.ContentTypeCache.now = func() time.Time { return now }
+
+	ct, err := cma.ContentTypes.GetCached(spaceID, "cat")
+	assert.Nil(err)
+	assert.Equal(FieldTypeInteger, ct.Field("lives").Type)
+
+	_, err = cma.ContentTypes.GetCached(spaceID, "cat")
+	assert.Nil(err)
+	assert.Equal(1, requests)
+
+	// other environments are cached separately
+	cma.SetEnvironment("staging")
+	_, err = cma.ContentTypes.GetCached(spaceID, "cat")
+	assert.Nil(err)
+	assert.Equal(2, requests)
+	cma.SetEnvironment("master")
+
+	// expired
+	now = now.Add(DefaultContentTypeCacheTTL)
+	_, err = cma.ContentTypes.GetCached(spaceID, "cat")
+	assert.Nil(err)
+	assert.Equal(3, requests)
+
+	// explicit invalidation
+	cma.ContentTypeCache.Invalidate(spaceID, "cat")
+	_, err = cma.ContentTypes.GetCached(spaceID, "cat")
+	assert.Nil(err)
+	assert.Equal(4, requests)
+
+	cma.ContentTypeCache.InvalidateSpace(spaceID)
+	_, err = cma.ContentTypes.GetCached(spaceID, "cat")
+	assert.Nil(err)
+	assert.Equal(5, requests)
+
+	cma.ContentTypeCache.Clear()
+	_, err = cma.ContentTypes.GetCached(spaceID, "cat")
+	assert.Nil(err)
+	assert.Equal(6, requests)
+
+	// disabled cache
+	cma.ContentTypeCache = nil
+	_, err = cma.ContentTypes.GetCached(spaceID, "cat")
+	assert.Nil(err)
+	_, err = cma.ContentTypes.GetCached(spaceID, "cat")
+	assert.Nil(err)
+	assert.Equal(8, requests)
+}
+
+func TestContentTypesServiceWritesInvalidateCache(t *testing.T) {
+	assert := assert.New(t)
+
+	requests := 0
+	server := contentTypeCacheServer(&requests)
+	defer server.Close()
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	writes := []func(ct *ContentType) error{
+		func(ct *ContentType) error { return cma.ContentTypes.Upsert(spaceID, ct) },
+		func(ct *ContentType) error { return cma.ContentTypes.Activate(spaceID, ct) },
+		func(ct *ContentType) error { return cma.ContentTypes.Deactivate(spaceID, ct) },
+		func(ct *ContentType) error { return cma.ContentTypes.Delete(spaceID, ct) },
+	}
+
+	for i, write := range writes {
+		ct, err := cma.ContentTypes.GetCached(spaceID, "cat")
+		assert.Nil(err)
+		assert.Equal(i+1, requests)
+
+		err = write(&ContentType{Sys: &Sys{ID: ct.Sys.ID, Version: ct.Sys.Version}})
+		assert.Nil(err)
+	}
+
+	_, err := cma.ContentTypes.GetCached(spaceID, "cat")
+	assert.Nil(err)
+	assert.Equal(5, requests)
+}
+
+func TestEntriesServiceGetEntryKeyUsesCache(t *testing.T) {
+	assert := assert.New(t)
+
+	requests := 0
+	server := contentTypeCacheServer(&requests)
+	defer server.Close()
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	entry := &Entry{
+		Sys: &Sys{
+			Space:       &Space{Sys: &Sys{ID: spaceID}},
+			ContentType: &ContentType{Sys: &Sys{ID: "cat"}},
+		},
+		Fields: map[string]interface{}{
+			"lives": map[string]interface{}{"en-US": float64(9)},
+		},
+	}
+
+	for i := 0; i < 3; i++ {
+		field, err := cma.Entries.GetEntryKey(entry, "lives")
+		assert.Nil(err)
+		assert.Equal(FieldTypeInteger, field.Type())
+	}
+	assert.Equal(1, requests)
+
+	_, err := cma.Entries.GetEntryKey(&Entry{Sys: &Sys{}}, "lives")
+	assert.NotNil(err)
+}
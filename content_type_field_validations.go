@@ -2,6 +2,7 @@ package contentful
 
 import (
 	"encoding/json"
+	"fmt"
 	"time"
 )
 
@@ -11,6 +12,7 @@ type FieldValidation interface{}
 // FieldValidationLink model
 type FieldValidationLink struct {
 	LinkContentType []string `json:"linkContentType,omitempty"`
+	ErrorMessage    string   `json:"message,omitempty"`
 }
 
 const (
@@ -53,19 +55,61 @@ const (
 
 // FieldValidationMimeType model
 type FieldValidationMimeType struct {
-	MimeTypes []string `json:"linkMimetypeGroup,omitempty"`
+	MimeTypes    []string `json:"linkMimetypeGroup,omitempty"`
+	ErrorMessage string   `json:"message,omitempty"`
 }
 
-// MinMax model
+// MinMax model, a nil bound is unset while a zero bound is enforced
 type MinMax struct {
-	Min float64 `json:"min,omitempty"`
-	Max float64 `json:"max,omitempty"`
+	Min *float64 `json:"min,omitempty"`
+	Max *float64 `json:"max,omitempty"`
+}
+
+// NewMinMax returns a range with both bounds set
+func NewMinMax(min, max float64) *MinMax {
+	return &MinMax{Min: &min, Max: &max}
+}
+
+// NewMin returns a range with a lower bound only
+func NewMin(min float64) *MinMax {
+	return &MinMax{Min: &min}
+}
+
+// NewMax returns a range with an upper bound only
+func NewMax(max float64) *MinMax {
+	return &MinMax{Max: &max}
+}
+
+// inMinMax checks a number against a range, nil bounds are unset
+func inMinMax(n float64, mm *MinMax) bool {
+	if mm == nil {
+		return true
+	}
+
+	return (mm.Min == nil || n >= *mm.Min) && (mm.Max == nil || n <= *mm.Max)
+}
+
+func describeMinMax(mm *MinMax) string {
+	switch {
+	case mm.Min != nil && mm.Max != nil:
+		return fmt.Sprintf("between %v and %v", *mm.Min, *mm.Max)
+	case mm.Min != nil:
+		return fmt.Sprintf("at least %v", *mm.Min)
+	case mm.Max != nil:
+		return fmt.Sprintf("at most %v", *mm.Max)
+	}
+
+	return "of any size"
 }
 
 // DateMinMax model
 type DateMinMax struct {
 	Min time.Time `json:"min,omitempty"`
 	Max time.Time `json:"max,omitempty"`
+
+	// the bounds as they were read, written back as long as they are unchanged
+	minRaw string
+	maxRaw string
 }
 
 // FieldValidationDimension model
@@ -76,7 +120,7 @@ type FieldValidationDimension struct {
 }
 
 // MarshalJSON for custom json marshaling
-func (v *FieldValidationDimension) MarshalJSON() ([]byte, error) {
+func (v FieldValidationDimension) MarshalJSON() ([]byte, error) {
 	type dimension struct {
 		Width  *MinMax `json:"width,omitempty"`
 		Height *MinMax `json:"height,omitempty"`
@@ -101,17 +145,17 @@ func (v *FieldValidationDimension) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	dimensionData := payload["assetImageDimensions"].(map[string]interface{})
+	dimensionData, _ := payload["assetImageDimensions"].(map[string]interface{})
 
 	if width, ok := dimensionData["width"].(map[string]interface{}); ok {
 		v.Width = &MinMax{}
 
 		if min, ok := width["min"].(float64); ok {
-			v.Width.Min = min
+			v.Width.Min = &min
 		}
 
-		if max, ok := width["min"].(float64); ok {
-			v.Width.Max = max
+		if max, ok := width["max"].(float64); ok {
+			v.Width.Max = &max
 		}
 	}
 
@@ -119,11 +163,11 @@ func (v *FieldValidationDimension) UnmarshalJSON(data []byte) error {
 		v.Height = &MinMax{}
 
 		if min, ok := height["min"].(float64); ok {
-			v.Height.Min = min
+			v.Height.Min = &min
 		}
 
 		if max, ok := height["max"].(float64); ok {
-			v.Height.Max = max
+			v.Height.Max = &max
 		}
 	}
 
@@ -142,13 +186,14 @@ type FieldValidationFileSize struct {
 
 // FieldValidationUnique model
 type FieldValidationUnique struct {
-	Unique bool `json:"unique"`
+	Unique       bool   `json:"unique"`
+	ErrorMessage string `json:"message,omitempty"`
 }
 
 // FieldValidationPredefinedValues model
 type FieldValidationPredefinedValues struct {
 	In           []interface{} `json:"in,omitempty"`
-	ErrorMessage string        `json:"message"`
+	ErrorMessage string        `json:"message,omitempty"`
 }
 
 // FieldValidationRange model
@@ -163,23 +208,86 @@ type FieldValidationDate struct {
 	ErrorMessage string      `json:"message,omitempty"`
 }
 
+// FieldValidationDateLayout is the format of the bounds of date range validations
+const FieldValidationDateLayout = "2006-01-02T15:04:05"
+
+// parseValidationDate reads the bound of a date range, which the web app writes
+// without a time zone and other clients with one
+func parseValidationDate(value string) (time.Time, error) {
+	date, err := time.Parse(FieldValidationDateLayout, value)
+	if err == nil {
+		return date, nil
+	}
+
+	if date, rfcErr := time.Parse(time.RFC3339, value); rfcErr == nil {
+		return date, nil
+	}
+
+	if date, dayErr := time.Parse("2006-01-02", value); dayErr == nil {
+		return date, nil
+	}
+
+	return time.Time{}, err
+}
+
+// describeDateMinMax describes the bounds of a date range which are set
+func describeDateMinMax(dmm *DateMinMax) string {
+	min, max := dmm.Min.Format(FieldValidationDateLayout), dmm.Max.Format(FieldValidationDateLayout)
+
+	switch {
+	case !dmm.Min.IsZero() && !dmm.Max.IsZero():
+		return fmt.Sprintf("between %s and %s", min, max)
+	case !dmm.Min.IsZero():
+		return fmt.Sprintf("on or after %s", min)
+	case !dmm.Max.IsZero():
+		return fmt.Sprintf("on or before %s", max)
+	}
+
+	return "any date"
+}
+
+// formatValidationDate writes the bound of a date range, keeping the string it
+// was read from or else the offset of a zone other than UTC
+func formatValidationDate(date time.Time, raw string) string {
+	if raw != "" {
+		if parsed, err := parseValidationDate(raw); err == nil && parsed.Equal(date) {
+			return raw
+		}
+	}
+
+	if date.Location() == time.UTC {
+		return date.Format(FieldValidationDateLayout)
+	}
+
+	return date.Format(time.RFC3339)
+}
+
 // MarshalJSON for custom json marshaling
-func (v *FieldValidationDate) MarshalJSON() ([]byte, error) {
+func (v FieldValidationDate) MarshalJSON() ([]byte, error) {
 	type dateRange struct {
 		Min string `json:"min,omitempty"`
 		Max string `json:"max,omitempty"`
 	}
 
-	return json.Marshal(&struct {
-		DateRange *dateRange `json:"dateRange,omitempty"`
+	payload := &struct {
+		DateRange *dateRange `json:"dateRange"`
 		Message   string     `json:"message,omitempty"`
 	}{
-		DateRange: &dateRange{
-			Min: v.Range.Max.Format("2006-01-02T03:04:05"),
-			Max: v.Range.Max.Format("2006-01-02T03:04:05"),
-		},
-		Message: v.ErrorMessage,
-	})
+		DateRange: &dateRange{},
+		Message:   v.ErrorMessage,
+	}
+
+	if v.Range != nil {
+		if !v.Range.Min.IsZero() {
+			payload.DateRange.Min = formatValidationDate(v.Range.Min, v.Range.minRaw)
+		}
+
+		if !v.Range.Max.IsZero() {
+			payload.DateRange.Max = formatValidationDate(v.Range.Max, v.Range.maxRaw)
+		}
+	}
+
+	return json.Marshal(payload)
 }
 
 // UnmarshalJSON for custom json unmarshaling
@@ -189,26 +297,28 @@ func (v *FieldValidationDate) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	dateRangeData := payload["dateRange"].(map[string]interface{})
+	dateRangeData, _ := payload["dateRange"].(map[string]interface{})
 
 	v.Range = &DateMinMax{}
 
 	if min, ok := dateRangeData["min"].(string); ok {
-		minDate, err := time.Parse("2006-01-02T03:04:05", min)
+		minDate, err := parseValidationDate(min)
 		if err != nil {
 			return err
 		}
 
 		v.Range.Min = minDate
+		v.Range.minRaw = min
 	}
 
 	if max, ok := dateRangeData["max"].(string); ok {
-		maxDate, err := time.Parse("2006-01-02T03:04:05", max)
+		maxDate, err := parseValidationDate(max)
 		if err != nil {
 			return err
 		}
 
 		v.Range.Max = maxDate
+		v.Range.maxRaw = max
 	}
 
 	if val, ok := payload["message"].(string); ok {
@@ -261,3 +371,73 @@ type FieldValidationRegex struct {
 	Regex        *Regex `json:"regexp,omitempty"`
 	ErrorMessage string `json:"message,omitempty"`
 }
+
+// FieldValidationProhibitRegex model rejects values matching the pattern
+type FieldValidationProhibitRegex struct {
+	Regex        *Regex `json:"prohibitRegexp,omitempty"`
+	ErrorMessage string `json:"message,omitempty"`
+}
+
+// FieldValidationEnabledNodeTypes model lists the block and inline node types allowed in a rich text field
+type FieldValidationEnabledNodeTypes struct {
+	NodeTypes    []string `json:"enabledNodeTypes"`
+	ErrorMessage string   `json:"message,omitempty"`
+}
+
+// FieldValidationEnabledMarks model lists the marks allowed in a rich text field
+type FieldValidationEnabledMarks struct {
+	Marks        []string `json:"enabledMarks"`
+	ErrorMessage string   `json:"message,omitempty"`
+}
+
+// FieldValidationNodes model holds the validations of the rich text nodes of a type,
+// like the content types of embedded entries or the number of embeds
+type FieldValidationNodes struct {
+	Nodes map[string][]FieldValidation `json:"nodes"`
+}
+
+// UnmarshalJSON for custom json unmarshaling
+func (v *FieldValidationNodes) UnmarshalJSON(data []byte) error {
+	payload := map[string]map[string][]interface{}{}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	v.Nodes = map[string][]FieldValidation{}
+	for nodeType, data := range payload["nodes"] {
+		validations, err := ParseValidations(data)
+		if err != nil {
+			return err
+		}
+
+		if validations == nil {
+			validations = []FieldValidation{}
+		}
+
+		v.Nodes[nodeType] = validations
+	}
+
+	return nil
+}
+
+// FieldValidationUnknown model keeps a validation the SDK has no model for,
+// it is written back unchanged when the content type is upserted
+type FieldValidationUnknown struct {
+	Raw json.RawMessage
+}
+
+// MarshalJSON for custom json marshaling
+func (v FieldValidationUnknown) MarshalJSON() ([]byte, error) {
+	if len(v.Raw) == 0 {
+		return []byte("{}"), nil
+	}
+
+	return v.Raw, nil
+}
+
+// UnmarshalJSON for custom json unmarshaling
+func (v *FieldValidationUnknown) UnmarshalJSON(data []byte) error {
+	v.Raw = append(json.RawMessage{}, data...)
+
+	return nil
+}
@@ -55,10 +55,7 @@ func TestFieldValidationRange(t *testing.T) {
 
 	// between
 	validation := &FieldValidationRange{
-		Range: &MinMax{
-			Min: 60,
-			Max: 100,
-		},
+		Range:        NewMinMax(60, 100),
 		ErrorMessage: "error message",
 	}
 	data, err := json.Marshal(validation)
@@ -68,15 +65,13 @@ func TestFieldValidationRange(t *testing.T) {
 	var validationCheck FieldValidationRange
 	err = json.NewDecoder(bytes.NewReader(data)).Decode(&validationCheck)
 	assert.Nil(err)
-	assert.Equal(float64(60), validationCheck.Range.Min)
-	assert.Equal(float64(100), validationCheck.Range.Max)
+	assert.Equal(float64(60), *validationCheck.Range.Min)
+	assert.Equal(float64(100), *validationCheck.Range.Max)
 	assert.Equal("error message", validationCheck.ErrorMessage)
 
 	// greater than equal to
 	validation = &FieldValidationRange{
-		Range: &MinMax{
-			Min: 10,
-		},
+		Range:        NewMin(10),
 		ErrorMessage: "error message",
 	}
 	data, err = json.Marshal(validation)
@@ -85,15 +80,13 @@ func TestFieldValidationRange(t *testing.T) {
 	validationCheck = FieldValidationRange{}
 	err = json.NewDecoder(bytes.NewReader(data)).Decode(&validationCheck)
 	assert.Nil(err)
-	assert.Equal(float64(10), validationCheck.Range.Min)
-	assert.Equal(float64(0), validationCheck.Range.Max)
+	assert.Equal(float64(10), *validationCheck.Range.Min)
+	assert.Nil(validationCheck.Range.Max)
 	assert.Equal("error message", validationCheck.ErrorMessage)
 
 	// less than equal to
 	validation = &FieldValidationRange{
-		Range: &MinMax{
-			Max: 90,
-		},
+		Range:        NewMax(90),
 		ErrorMessage: "error message",
 	}
 	data, err = json.Marshal(validation)
@@ -102,8 +95,8 @@ func TestFieldValidationRange(t *testing.T) {
 	validationCheck = FieldValidationRange{}
 	err = json.NewDecoder(bytes.NewReader(data)).Decode(&validationCheck)
 	assert.Nil(err)
-	assert.Equal(float64(90), validationCheck.Range.Max)
-	assert.Equal(float64(0), validationCheck.Range.Min)
+	assert.Equal(float64(90), *validationCheck.Range.Max)
+	assert.Nil(validationCheck.Range.Min)
 	assert.Equal("error message", validationCheck.ErrorMessage)
 }
 
@@ -113,10 +106,7 @@ func TestFieldValidationSize(t *testing.T) {
 
 	// between
 	validation := &FieldValidationSize{
-		Size: &MinMax{
-			Min: 4,
-			Max: 6,
-		},
+		Size:         NewMinMax(4, 6),
 		ErrorMessage: "error message",
 	}
 	data, err := json.Marshal(validation)
@@ -126,8 +116,8 @@ func TestFieldValidationSize(t *testing.T) {
 	var validationCheck FieldValidationSize
 	err = json.NewDecoder(bytes.NewReader(data)).Decode(&validationCheck)
 	assert.Nil(err)
-	assert.Equal(float64(4), validationCheck.Size.Min)
-	assert.Equal(float64(6), validationCheck.Size.Max)
+	assert.Equal(float64(4), *validationCheck.Size.Min)
+	assert.Equal(float64(6), *validationCheck.Size.Max)
 	assert.Equal("error message", validationCheck.ErrorMessage)
 }
 
@@ -135,9 +125,9 @@ func TestFieldValidationDate(t *testing.T) {
 	var err error
 	assert := assert.New(t)
 
-	layout := "2006-01-02T03:04:05"
-	min := time.Now()
-	max := time.Now()
+	layout := FieldValidationDateLayout
+	min := time.Date(2017, 3, 15, 10, 0, 0, 0, time.UTC)
+	max := time.Date(2017, 3, 31, 23, 30, 0, 0, time.UTC)
 
 	minStr := min.Format(layout)
 	maxStr := max.Format(layout)
@@ -160,3 +150,124 @@ func TestFieldValidationDate(t *testing.T) {
 	assert.Equal(maxStr, validationCheck.Range.Max.Format(layout))
 	assert.Equal("error message", validationCheck.ErrorMessage)
 }
+
+func TestFieldValidationDateParse(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	var validation FieldValidationDate
+	err = json.Unmarshal([]byte(`{"dateRange": {"min": "2017-03-15", "max": "2017-03-31T23:30:00Z", "after": null}}`), &validation)
+	assert.Nil(err)
+	assert.Equal(time.Date(2017, 3, 15, 0, 0, 0, 0, time.UTC), validation.Range.Min)
+	assert.Equal(time.Date(2017, 3, 31, 23, 30, 0, 0, time.UTC), validation.Range.Max)
+
+	data, err := json.Marshal(FieldValidationDate{Range: &DateMinMax{Max: validation.Range.Max}})
+	assert.Nil(err)
+	assert.Equal(`{"dateRange":{"max":"2017-03-31T23:30:00"}}`, string(data))
+
+	err = json.Unmarshal([]byte(`{"dateRange": {"min": "yesterday"}}`), &validation)
+	assert.NotNil(err)
+}
+
+func TestFieldValidationDateOffset(t *testing.T) {
+	assert := assert.New(t)
+
+	var validation FieldValidationDate
+	raw := `{"dateRange":{"min":"2017-03-15T10:00:00+02:00","max":"2017-03-31"}}`
+	assert.Nil(json.Unmarshal([]byte(raw), &validation))
+	assert.Equal(time.Date(2017, 3, 15, 8, 0, 0, 0, time.UTC), validation.Range.Min.UTC())
+
+	data, err := json.Marshal(validation)
+	assert.Nil(err)
+	assert.Equal(raw, string(data))
+
+	// changed bounds keep their zone
+	validation.Range.Min = validation.Range.Min.Add(time.Hour)
+	data, err = json.Marshal(validation)
+	assert.Nil(err)
+	assert.Equal(`{"dateRange":{"min":"2017-03-15T11:00:00+02:00","max":"2017-03-31"}}`, string(data))
+}
+
+func TestFieldValidationZeroBounds(t *testing.T) {
+	assert := assert.New(t)
+
+	raw := `[
+		{"size": {"min": 0}},
+		{"range": {"max": 0}},
+		{"range": {"min": -5, "max": 0}},
+		{"assetFileSize": {"min": 0, "max": 0}},
+		{"assetImageDimensions": {"width": {"min": 0}, "height": {"max": 0}}}
+	]`
+
+	var data []interface{}
+	assert.Nil(json.Unmarshal([]byte(raw), &data))
+
+	validations, err := ParseValidations(data)
+	assert.Nil(err)
+	assert.Equal(NewMin(0), validations[0].(FieldValidationSize).Size)
+	assert.Equal(NewMax(0), validations[1].(FieldValidationRange).Range)
+	assert.Equal(NewMinMax(-5, 0), validations[2].(FieldValidationRange).Range)
+	assert.Equal(NewMinMax(0, 0), validations[3].(FieldValidationFileSize).Size)
+	assert.Equal(NewMin(0), validations[4].(FieldValidationDimension).Width)
+	assert.Equal(NewMax(0), validations[4].(FieldValidationDimension).Height)
+
+	marshaled, err := json.Marshal(validations)
+	assert.Nil(err)
+
+	var expected, actual interface{}
+	assert.Nil(json.Unmarshal([]byte(raw), &expected))
+	assert.Nil(json.Unmarshal(marshaled, &actual))
+	assert.Equal(expected, actual)
+}
+
+func TestParseValidationsRoundTrip(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	raw := `[
+		{"linkContentType": ["cat"], "message": "only cats"},
+		{"linkMimetypeGroup": ["image"], "message": "only images"},
+		{"assetImageDimensions": {"width": {"min": 100, "max": 200}, "height": {"max": 400}}, "message": "too big"},
+		{"assetFileSize": {"max": 1024}, "message": "too heavy"},
+		{"unique": true, "message": "taken"},
+		{"in": ["a", "b"]},
+		{"range": {"min": 1, "max": 9}, "message": "out of range"},
+		{"dateRange": {"min": "2017-03-15T10:00:00", "max": "2017-03-31T23:30:00"}, "message": "out of time"},
+		{"size": {"max": 20}},
+		{"regexp": {"pattern": "^cat", "flags": "i"}, "message": "not a cat"},
+		{"prohibitRegexp": {"pattern": "dog", "flags": null}, "message": "no dogs"},
+		{"enabledNodeTypes": ["hyperlink"]},
+		{"enabledMarks": ["bold"]},
+		{"nodes": {"embedded-entry-block": [{"size": {"max": 2}}]}},
+		{"someFutureValidation": {"answer": 42}, "message": "from the future"}
+	]`
+
+	var data []interface{}
+	err = json.Unmarshal([]byte(raw), &data)
+	assert.Nil(err)
+
+	validations, err := ParseValidations(data)
+	assert.Nil(err)
+	assert.Equal(len(data), len(validations))
+
+	assert.Equal("only cats", validations[0].(FieldValidationLink).ErrorMessage)
+	assert.Equal("only images", validations[1].(FieldValidationMimeType).ErrorMessage)
+	assert.Equal(NewMinMax(100, 200), validations[2].(FieldValidationDimension).Width)
+	assert.Equal("taken", validations[4].(FieldValidationUnique).ErrorMessage)
+	assert.Equal("dog", validations[10].(FieldValidationProhibitRegex).Regex.Pattern)
+
+	unknown, ok := validations[14].(FieldValidationUnknown)
+	assert.True(ok)
+	assert.Contains(string(unknown.Raw), "someFutureValidation")
+
+	marshaled, err := json.Marshal(validations)
+	assert.Nil(err)
+
+	var expected, actual interface{}
+	assert.Nil(json.Unmarshal([]byte(raw), &expected))
+	assert.Nil(json.Unmarshal(marshaled, &actual))
+
+	// a null flag is the same as no flag
+	delete(expected.([]interface{})[10].(map[string]interface{})["prohibitRegexp"].(map[string]interface{}), "flags")
+	assert.Equal(expected, actual)
+}
@@ -669,10 +669,7 @@ func TestContentTypeFieldValidationRangeUniquePredefinedValues(t *testing.T) {
 				Unique: false,
 			},
 			&FieldValidationRange{
-				Range: &MinMax{
-					Min: 20,
-					Max: 30,
-				},
+				Range:        NewMinMax(20, 30),
 				ErrorMessage: "error message",
 			},
 			&FieldValidationPredefinedValues{
@@ -800,19 +797,12 @@ func TestContentTypeFieldTypeMedia(t *testing.T) {
 				},
 			},
 			&FieldValidationDimension{
-				Width: &MinMax{
-					Min: 100,
-				},
-				Height: &MinMax{
-					Max: 300,
-				},
+				Width:        NewMin(100),
+				Height:       NewMax(300),
 				ErrorMessage: "custom error message",
 			},
 			&FieldValidationFileSize{
-				Size: &MinMax{
-					Min: 30,
-					Max: 400,
-				},
+				Size: NewMinMax(30, 400),
 			},
 		},
 	}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"log"
 	"net/http"
 	"net/http/httputil"
@@ -23,16 +24,25 @@ type Client struct {
 	QueryParams   map[string]string
 	Headers       map[string]string
 	BaseURL       string
+	UploadBaseURL string
 	Environment   string
 	commonService service
 
-	Spaces       *SpacesService
-	APIKeys      *APIKeyService
-	Assets       *AssetsService
-	ContentTypes *ContentTypesService
-	Entries      *EntriesService
-	Locales      *LocalesService
-	Webhooks     *WebhooksService
+	// ContentTypeCache holds the content types used by schema aware helpers, nil disables caching
+	ContentTypeCache *ContentTypeCache
+
+	Spaces           *SpacesService
+	APIKeys          *APIKeyService
+	Assets           *AssetsService
+	AssetKeys        *AssetKeysService
+	ContentTypes     *ContentTypesService
+	Entries          *EntriesService
+	Locales          *LocalesService
+	Webhooks         *WebhooksService
+	Releases         *ReleasesService
+	ScheduledActions *ScheduledActionsService
+	Tags             *TagsService
+	Uploads          *UploadsService
 }
 
 type service struct {
@@ -51,18 +61,26 @@ func NewCMA(token string) *Client {
 			"Content-Type":            "application/vnd.contentful.management.v1+json",
 			"X-Contentful-User-Agent": fmt.Sprintf("sdk contentful-go/%s", Version),
 		},
-		BaseURL:     "https://api.contentful.com",
-		Environment: "master",
+		BaseURL:       "https://api.contentful.com",
+		UploadBaseURL: "https://upload.contentful.com",
+		Environment:   "master",
+
+		ContentTypeCache: NewContentTypeCache(DefaultContentTypeCacheTTL),
 	}
 	c.commonService.c = c
 
 	c.Spaces = (*SpacesService)(&c.commonService)
 	c.APIKeys = (*APIKeyService)(&c.commonService)
 	c.Assets = (*AssetsService)(&c.commonService)
+	c.AssetKeys = (*AssetKeysService)(&c.commonService)
 	c.ContentTypes = (*ContentTypesService)(&c.commonService)
 	c.Entries = (*EntriesService)(&c.commonService)
 	c.Locales = (*LocalesService)(&c.commonService)
 	c.Webhooks = (*WebhooksService)(&c.commonService)
+	c.Releases = (*ReleasesService)(&c.commonService)
+	c.ScheduledActions = (*ScheduledActionsService)(&c.commonService)
+	c.Tags = (*TagsService)(&c.commonService)
+	c.Uploads = (*UploadsService)(&c.commonService)
 	return c
 }
 
@@ -80,16 +98,23 @@ func NewCDA(token string) *Client {
 		},
 		BaseURL:     "https://cdn.contentful.com",
 		Environment: "master",
+
+		ContentTypeCache: NewContentTypeCache(DefaultContentTypeCacheTTL),
 	}
 	c.commonService.c = c
 
 	c.Spaces = (*SpacesService)(&c.commonService)
 	c.APIKeys = (*APIKeyService)(&c.commonService)
 	c.Assets = (*AssetsService)(&c.commonService)
+	c.AssetKeys = (*AssetKeysService)(&c.commonService)
 	c.ContentTypes = (*ContentTypesService)(&c.commonService)
 	c.Entries = (*EntriesService)(&c.commonService)
 	c.Locales = (*LocalesService)(&c.commonService)
 	c.Webhooks = (*WebhooksService)(&c.commonService)
+	c.Releases = (*ReleasesService)(&c.commonService)
+	c.ScheduledActions = (*ScheduledActionsService)(&c.commonService)
+	c.Tags = (*TagsService)(&c.commonService)
+	c.Uploads = (*UploadsService)(&c.commonService)
 
 	return c
 }
@@ -105,15 +130,22 @@ func NewCPA(token string) *Client {
 			"Authorization": "Bearer " + token,
 		},
 		BaseURL: "https://preview.contentful.com",
+
+		ContentTypeCache: NewContentTypeCache(DefaultContentTypeCacheTTL),
 	}
 
 	c.Spaces = &SpacesService{c: c}
 	c.APIKeys = &APIKeyService{c: c}
 	c.Assets = &AssetsService{c: c}
+	c.AssetKeys = &AssetKeysService{c: c}
 	c.ContentTypes = &ContentTypesService{c: c}
 	c.Entries = &EntriesService{c: c}
 	c.Locales = &LocalesService{c: c}
 	c.Webhooks = &WebhooksService{c: c}
+	c.Releases = &ReleasesService{c: c}
+	c.ScheduledActions = &ScheduledActionsService{c: c}
+	c.Tags = &TagsService{c: c}
+	c.Uploads = &UploadsService{c: c}
 
 	return c
 }
@@ -223,33 +255,17 @@ func (c *Client) handleError(req *http.Request, res *http.Response) error {
 		fmt.Printf("%q", dump)
 	}
 
-	var e ErrorResponse
 	defer res.Body.Close()
-	err := json.NewDecoder(res.Body).Decode(&e)
+	body, err := ioutil.ReadAll(res.Body)
 	if err != nil {
 		return err
 	}
 
-	apiError := APIError{
-		req: req,
-		res: res,
-		err: &e,
-	}
-
-	switch errType := e.Sys.ID; errType {
-	case "NotFound":
-		return NotFoundError{apiError}
-	case "RateLimitExceeded":
-		return RateLimitExceededError{apiError}
-	case "AccessTokenInvalid":
-		return AccessTokenInvalidError{apiError}
-	case "ValidationFailed":
-		return ValidationFailedError{apiError}
-	case "VersionMismatch":
-		return VersionMismatchError{apiError}
-	case "Conflict":
-		return VersionMismatchError{apiError}
-	default:
-		return e
+	// gateways answer with html pages, the status is kept as message
+	var e ErrorResponse
+	if err := json.Unmarshal(body, &e); err != nil || e.Sys == nil {
+		e = ErrorResponse{Message: res.Status}
 	}
+
+	return newAPIError(req, res, body, &e)
 }
@@ -141,6 +141,7 @@ func TestContentfulNewCMA(t *testing.T) {
 	cma := NewCMA(CMAToken)
 	assert.IsType(Client{}, *cma)
 	assert.Equal("https://api.contentful.com", cma.BaseURL)
+	assert.Equal("https://upload.contentful.com", cma.UploadBaseURL)
 	assert.Equal("CMA", cma.api)
 	assert.Equal(CMAToken, cma.token)
 	assert.Equal(fmt.Sprintf("Bearer %s", CMAToken), cma.Headers["Authorization"])
@@ -14,9 +14,10 @@ type EntriesService service
 
 //Entry model
 type Entry struct {
-	locale string
-	Sys    *Sys `json:"sys"`
-	Fields map[string]interface{}
+	locale   string
+	Sys      *Sys      `json:"sys"`
+	Metadata *Metadata `json:"metadata,omitempty"`
+	Fields   map[string]interface{}
 }
 
 // GetVersion returns entity version
@@ -29,32 +30,20 @@ func (entry *Entry) GetVersion() int {
 	return version
 }
 
-// GetEntryKey returns the entry's keys
+// GetEntryKey returns the entry's keys.
+// The content type of the entry is read through the client content type cache.
 func (service *EntriesService) GetEntryKey(entry *Entry, key string) (*EntryField, error) {
-	ef := EntryField{
-		value: entry.Fields[key],
+	if entry.Sys == nil || entry.Sys.Space == nil || entry.Sys.Space.Sys == nil ||
+		entry.Sys.ContentType == nil || entry.Sys.ContentType.Sys == nil {
+		return nil, fmt.Errorf("entry requires a space and a content type")
 	}
 
-	col, err := service.c.ContentTypes.List(entry.Sys.Space.Sys.ID).Next()
+	ct, err := service.c.ContentTypes.GetCached(entry.Sys.Space.Sys.ID, entry.Sys.ContentType.Sys.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, ct := range col.ToContentType() {
-		if ct.Sys.ID != entry.Sys.ContentType.Sys.ID {
-			continue
-		}
-
-		for _, field := range ct.Fields {
-			if field.ID != key {
-				continue
-			}
-
-			ef.dataType = field.Type
-		}
-	}
-
-	return &ef, nil
+	return NewEntryField(entry, ct.Field(key), key), nil
 }
 
 // List returns entries collection
@@ -98,6 +87,10 @@ func (service *EntriesService) Upsert(spaceID string, entry *Entry) error {
 		"fields": entry.Fields,
 	}
 
+	if entry.Metadata != nil {
+		fields["metadata"] = entry.Metadata
+	}
+
 	bytesArray, err := json.Marshal(fields)
 	if err != nil {
 		return err
@@ -175,3 +168,32 @@ func (service *EntriesService) Unpublish(spaceID string, entry *Entry) error {
 
 	return service.c.do(req, nil)
 }
+
+// Decode reads the sys and the field values of the locale into v, such as the structs generated by
+// the codegen package. Fields are decoded by their id, fields without a value for the locale are left out.
+// An empty locale reads the values of a single locale response as is.
+func (entry *Entry) Decode(locale string, v interface{}) error {
+	if entry.Sys != nil && entry.Sys.Locale != "" {
+		locale = ""
+	}
+
+	values := map[string]interface{}{
+		"sys": entry.Sys,
+	}
+
+	for id := range entry.Fields {
+		value, err := NewEntryField(entry, nil, id).Value(locale)
+		if err != nil {
+			continue
+		}
+
+		values[id] = value
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,157 @@
+package contentful
+
+import (
+	"reflect"
+	"sort"
+)
+
+const (
+	// FieldChangeAdded the field value exists only in the newer entry
+	FieldChangeAdded = "added"
+
+	// FieldChangeRemoved the field value exists only in the older entry
+	FieldChangeRemoved = "removed"
+
+	// FieldChangeModified the field value differs between the entries
+	FieldChangeModified = "modified"
+)
+
+// FieldChange model describes the change of a single field locale
+type FieldChange struct {
+	FieldID string
+	Locale  string
+	Kind    string
+	From    interface{}
+	To      interface{}
+}
+
+// EntryDiff model
+type EntryDiff struct {
+	Changes []*FieldChange
+}
+
+// DiffEntries compares the fields of two entries locale by locale.
+// Field values are expected in their localized form, as returned by the CMA;
+// values which are not keyed by locale are reported under an empty locale.
+func DiffEntries(from, to *Entry) *EntryDiff {
+	fromFields := normalizedFields(from)
+	toFields := normalizedFields(to)
+
+	fieldIDs := map[string]bool{}
+	for id := range fromFields {
+		fieldIDs[id] = true
+	}
+	for id := range toFields {
+		fieldIDs[id] = true
+	}
+
+	ids := make([]string, 0, len(fieldIDs))
+	for id := range fieldIDs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	diff := &EntryDiff{Changes: []*FieldChange{}}
+
+	for _, id := range ids {
+		fromLocales := localizedValues(fromFields[id])
+		toLocales := localizedValues(toFields[id])
+
+		locales := map[string]bool{}
+		for locale := range fromLocales {
+			locales[locale] = true
+		}
+		for locale := range toLocales {
+			locales[locale] = true
+		}
+
+		codes := make([]string, 0, len(locales))
+		for locale := range locales {
+			codes = append(codes, locale)
+		}
+		sort.Strings(codes)
+
+		for _, locale := range codes {
+			fromValue, inFrom := fromLocales[locale]
+			toValue, inTo := toLocales[locale]
+
+			change := &FieldChange{
+				FieldID: id,
+				Locale:  locale,
+				From:    fromValue,
+				To:      toValue,
+			}
+
+			switch {
+			case !inFrom:
+				change.Kind = FieldChangeAdded
+			case !inTo:
+				change.Kind = FieldChangeRemoved
+			case !reflect.DeepEqual(fromValue, toValue):
+				change.Kind = FieldChangeModified
+			default:
+				continue
+			}
+
+			diff.Changes = append(diff.Changes, change)
+		}
+	}
+
+	return diff
+}
+
+// Empty reports whether the entries have identical fields
+func (diff *EntryDiff) Empty() bool {
+	return len(diff.Changes) == 0
+}
+
+// Fields returns the ids of the changed fields
+func (diff *EntryDiff) Fields() []string {
+	fields := []string{}
+
+	for _, change := range diff.Changes {
+		if len(fields) == 0 || fields[len(fields)-1] != change.FieldID {
+			fields = append(fields, change.FieldID)
+		}
+	}
+
+	return fields
+}
+
+// Field returns the changes of a single field
+func (diff *EntryDiff) Field(fieldID string) []*FieldChange {
+	changes := []*FieldChange{}
+
+	for _, change := range diff.Changes {
+		if change.FieldID == fieldID {
+			changes = append(changes, change)
+		}
+	}
+
+	return changes
+}
+
+func normalizedFields(entry *Entry) map[string]interface{} {
+	if entry == nil || entry.Fields == nil {
+		return map[string]interface{}{}
+	}
+
+	fields, err := copyFields(entry.Fields)
+	if err != nil {
+		return map[string]interface{}{}
+	}
+
+	return fields
+}
+
+func localizedValues(value interface{}) map[string]interface{} {
+	if value == nil {
+		return map[string]interface{}{}
+	}
+
+	if locales, ok := value.(map[string]interface{}); ok {
+		return locales
+	}
+
+	return map[string]interface{}{"": value}
+}
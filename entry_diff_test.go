@@ -0,0 +1,65 @@
+package contentful
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffEntries(t *testing.T) {
+	assert := assert.New(t)
+
+	var snapshot EntrySnapshot
+	err := json.Unmarshal([]byte(readTestData("entry_snapshot.json")), &snapshot)
+	assert.Nil(err)
+
+	var entry Entry
+	err = json.Unmarshal([]byte(readTestData("entry_3.json")), &entry)
+	assert.Nil(err)
+
+	diff := snapshot.Diff(&entry)
+	assert.False(diff.Empty())
+	assert.Equal([]string{"bestFriend", "birthday", "color", "image", "likes"}, diff.Fields())
+
+	color := diff.Field("color")
+	assert.Equal(1, len(color))
+	assert.Equal("tlh", color[0].Locale)
+	assert.Equal(FieldChangeRemoved, color[0].Kind)
+	assert.Equal("rainbow", color[0].From)
+	assert.Nil(color[0].To)
+
+	likes := diff.Field("likes")
+	assert.Equal(1, len(likes))
+	assert.Equal("en-US", likes[0].Locale)
+	assert.Equal(FieldChangeModified, likes[0].Kind)
+	assert.Equal([]interface{}{"rainbows"}, likes[0].From)
+	assert.Equal([]interface{}{"rainbows", "fish"}, likes[0].To)
+
+	birthday := diff.Field("birthday")
+	assert.Equal(FieldChangeAdded, birthday[0].Kind)
+	assert.Equal("2011-04-04T22:00:00+00:00", birthday[0].To)
+
+	assert.Equal(0, len(diff.Field("name")))
+}
+
+func TestDiffEntriesIdentical(t *testing.T) {
+	assert := assert.New(t)
+
+	from := &Entry{
+		Fields: map[string]interface{}{
+			"title": map[string]string{"en-US": "hello"},
+		},
+	}
+	to := &Entry{
+		Fields: map[string]interface{}{
+			"title": map[string]interface{}{"en-US": "hello"},
+		},
+	}
+
+	assert.True(DiffEntries(from, to).Empty())
+
+	diff := DiffEntries(nil, to)
+	assert.Equal(1, len(diff.Changes))
+	assert.Equal(FieldChangeAdded, diff.Changes[0].Kind)
+}
@@ -1,121 +1,482 @@
 package contentful
 
-import "reflect"
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
 
 // EntryField model
 type EntryField struct {
+	id       string
 	value    interface{}
 	dataType string
+	field    *Field
+}
+
+// Location model holds the value of a location field
+type Location struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// FieldLocaleError is returned when a field has no value for the requested locale
+type FieldLocaleError struct {
+	FieldID string
+	Locale  string
+}
+
+func (e FieldLocaleError) Error() string {
+	if e.Locale == "" {
+		return fmt.Sprintf("field %q has no value", e.FieldID)
+	}
+
+	return fmt.Sprintf("field %q has no value for locale %q", e.FieldID, e.Locale)
+}
+
+// FieldTypeError is returned when a field value can not be read as the requested type
+type FieldTypeError struct {
+	FieldID  string
+	Expected string
+	Actual   string
+}
+
+func (e FieldTypeError) Error() string {
+	return fmt.Sprintf("field %q is %s, not %s", e.FieldID, e.Actual, e.Expected)
+}
+
+// dateFormats are the layouts accepted by date fields, from the most to the least precise
+var dateFormats = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+// NewEntryField returns the value of an entry field, typed according to its content type definition.
+// The definition is optional, without it values are read as whatever type is requested.
+func NewEntryField(entry *Entry, field *Field, fieldID string) *EntryField {
+	ef := &EntryField{
+		id:    fieldID,
+		value: entry.Fields[fieldID],
+		field: field,
+	}
+
+	if field != nil {
+		ef.dataType = field.Type
+	}
+
+	return ef
+}
+
+// Type returns the content type field type, empty when the definition is unknown
+func (ef *EntryField) Type() string {
+	return ef.dataType
+}
+
+// Value returns the raw value of the given locale.
+// An empty locale returns the value of a single locale response as is.
+func (ef *EntryField) Value(locale string) (interface{}, error) {
+	if locale == "" {
+		if ef.value == nil {
+			return nil, FieldLocaleError{FieldID: ef.id}
+		}
+
+		return ef.value, nil
+	}
+
+	// values set by hand may use any map keyed by locale, such as map[string]string
+	m := reflect.ValueOf(ef.value)
+	if m.Kind() != reflect.Map || m.Type().Key().Kind() != reflect.String {
+		return nil, FieldLocaleError{FieldID: ef.id, Locale: locale}
+	}
+
+	val := m.MapIndex(reflect.ValueOf(locale).Convert(m.Type().Key()))
+	if !val.IsValid() || val.Interface() == nil {
+		return nil, FieldLocaleError{FieldID: ef.id, Locale: locale}
+	}
+
+	return val.Interface(), nil
+}
+
+// checkType fails if the field definition is none of the given types
+func (ef *EntryField) checkType(types ...string) error {
+	if ef.dataType == "" {
+		return nil
+	}
+
+	for _, t := range types {
+		if ef.dataType == t {
+			return nil
+		}
+	}
+
+	return FieldTypeError{FieldID: ef.id, Expected: types[0], Actual: ef.dataType}
+}
+
+func (ef *EntryField) typedValue(locale string, types ...string) (interface{}, error) {
+	if err := ef.checkType(types...); err != nil {
+		return nil, err
+	}
+
+	return ef.Value(locale)
+}
+
+func (ef *EntryField) mismatch(expected string, val interface{}) error {
+	return FieldTypeError{FieldID: ef.id, Expected: expected, Actual: fmt.Sprintf("%T", val)}
+}
+
+// toFloat accepts decoded json numbers as well as numbers set by hand
+func toFloat(val interface{}) (float64, bool) {
+	switch n := val.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	}
+
+	return 0, false
+}
+
+// StringValue returns the value of a Symbol or Text field
+func (ef *EntryField) StringValue(locale string) (string, error) {
+	val, err := ef.typedValue(locale, FieldTypeSymbol, FieldTypeText)
+	if err != nil {
+		return "", err
+	}
+
+	s, ok := val.(string)
+	if !ok {
+		return "", ef.mismatch(FieldTypeSymbol, val)
+	}
+
+	return s, nil
+}
+
+// IntegerValue returns the value of an Integer field
+func (ef *EntryField) IntegerValue(locale string) (int, error) {
+	val, err := ef.typedValue(locale, FieldTypeInteger)
+	if err != nil {
+		return 0, err
+	}
+
+	f, ok := toFloat(val)
+	if !ok || f != float64(int(f)) {
+		return 0, ef.mismatch(FieldTypeInteger, val)
+	}
+
+	return int(f), nil
+}
+
+// NumberValue returns the value of a Number or Integer field
+func (ef *EntryField) NumberValue(locale string) (float64, error) {
+	val, err := ef.typedValue(locale, FieldTypeNumber, FieldTypeInteger)
+	if err != nil {
+		return 0, err
+	}
+
+	f, ok := toFloat(val)
+	if !ok {
+		return 0, ef.mismatch(FieldTypeNumber, val)
+	}
+
+	return f, nil
+}
+
+// BooleanValue returns the value of a Boolean field
+func (ef *EntryField) BooleanValue(locale string) (bool, error) {
+	val, err := ef.typedValue(locale, FieldTypeBoolean)
+	if err != nil {
+		return false, err
+	}
+
+	b, ok := val.(bool)
+	if !ok {
+		return false, ef.mismatch(FieldTypeBoolean, val)
+	}
+
+	return b, nil
+}
+
+// DateValue returns the value of a Date field.
+// Dates without a timezone are returned in UTC.
+func (ef *EntryField) DateValue(locale string) (time.Time, error) {
+	val, err := ef.typedValue(locale, FieldTypeDate)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	s, ok := val.(string)
+	if !ok {
+		return time.Time{}, ef.mismatch(FieldTypeDate, val)
+	}
+
+	for _, layout := range dateFormats {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, FieldTypeError{FieldID: ef.id, Expected: FieldTypeDate, Actual: fmt.Sprintf("string %q", s)}
+}
+
+// LocationValue returns the value of a Location field
+func (ef *EntryField) LocationValue(locale string) (*Location, error) {
+	val, err := ef.typedValue(locale, FieldTypeLocation)
+	if err != nil {
+		return nil, err
+	}
+
+	m, ok := val.(map[string]interface{})
+	if !ok {
+		return nil, ef.mismatch(FieldTypeLocation, val)
+	}
+
+	lat, latOK := toFloat(m["lat"])
+	lon, lonOK := toFloat(m["lon"])
+	if !latOK || !lonOK {
+		return nil, ef.mismatch(FieldTypeLocation, val)
+	}
+
+	return &Location{Lat: lat, Lon: lon}, nil
+}
+
+// ObjectValue returns the value of an Object field
+func (ef *EntryField) ObjectValue(locale string) (map[string]interface{}, error) {
+	val, err := ef.typedValue(locale, FieldTypeObject)
+	if err != nil {
+		return nil, err
+	}
+
+	m, ok := val.(map[string]interface{})
+	if !ok {
+		return nil, ef.mismatch(FieldTypeObject, val)
+	}
+
+	return m, nil
+}
+
+// RichTextValue returns the document of a RichText field
+func (ef *EntryField) RichTextValue(locale string) (map[string]interface{}, error) {
+	val, err := ef.typedValue(locale, FieldTypeRichText)
+	if err != nil {
+		return nil, err
+	}
+
+	m, ok := val.(map[string]interface{})
+	if !ok || m["nodeType"] != "document" {
+		return nil, ef.mismatch(FieldTypeRichText, val)
+	}
+
+	return m, nil
+}
+
+// ArrayValue returns the items of an Array field
+func (ef *EntryField) ArrayValue(locale string) ([]interface{}, error) {
+	val, err := ef.typedValue(locale, FieldTypeArray)
+	if err != nil {
+		return nil, err
+	}
+
+	if items, ok := val.([]interface{}); ok {
+		return items, nil
+	}
+
+	s := reflect.ValueOf(val)
+	if s.Kind() != reflect.Slice {
+		return nil, ef.mismatch(FieldTypeArray, val)
+	}
+
+	items := make([]interface{}, s.Len())
+	for i := range items {
+		items[i] = s.Index(i).Interface()
+	}
+
+	return items, nil
+}
+
+// SymbolsValue returns the items of an Array field of symbols
+func (ef *EntryField) SymbolsValue(locale string) ([]string, error) {
+	if ef.field != nil && ef.field.Items != nil && ef.field.Items.Type != FieldTypeSymbol {
+		return nil, FieldTypeError{FieldID: ef.id, Expected: "Array of Symbol", Actual: "Array of " + ef.field.Items.Type}
+	}
+
+	items, err := ef.ArrayValue(locale)
+	if err != nil {
+		return nil, err
+	}
+
+	res := []string{}
+	for _, item := range items {
+		s, ok := item.(string)
+		if !ok {
+			return nil, ef.mismatch("Array of Symbol", item)
+		}
+
+		res = append(res, s)
+	}
+
+	return res, nil
+}
+
+func toLink(val interface{}) (*Link, bool) {
+	m, ok := val.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	sys, ok := m["sys"].(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	id, _ := sys["id"].(string)
+	linkType, _ := sys["linkType"].(string)
+	if id == "" || linkType == "" {
+		return nil, false
+	}
+
+	return NewLink(linkType, id), true
+}
+
+// LinkValue returns the value of a Link field
+func (ef *EntryField) LinkValue(locale string) (*Link, error) {
+	val, err := ef.typedValue(locale, FieldTypeLink)
+	if err != nil {
+		return nil, err
+	}
+
+	link, ok := toLink(val)
+	if !ok {
+		return nil, ef.mismatch(FieldTypeLink, val)
+	}
+
+	return link, nil
+}
+
+// LinksValue returns the items of an Array field of links
+func (ef *EntryField) LinksValue(locale string) ([]*Link, error) {
+	if ef.field != nil && ef.field.Items != nil && ef.field.Items.Type != FieldTypeLink {
+		return nil, FieldTypeError{FieldID: ef.id, Expected: "Array of Link", Actual: "Array of " + ef.field.Items.Type}
+	}
+
+	items, err := ef.ArrayValue(locale)
+	if err != nil {
+		return nil, err
+	}
+
+	res := []*Link{}
+	for _, item := range items {
+		link, ok := toLink(item)
+		if !ok {
+			return nil, ef.mismatch("Array of Link", item)
+		}
+
+		res = append(res, link)
+	}
+
+	return res, nil
 }
 
 // String converts interface to string
 func (ef *EntryField) String() string {
-	return ef.value.(string)
+	val, err := ef.StringValue("")
+	if err != nil {
+		panic(err)
+	}
+
+	return val
 }
 
 //LString returns the given locale
 func (ef *EntryField) LString(locale string) string {
-	m := ef.value.(map[string]interface{})
-
-	if val, ok := m[locale]; ok {
-		return val.(string)
+	val, err := ef.StringValue(locale)
+	if err != nil {
+		panic(err)
 	}
 
-	panic("no such a locale")
+	return val
 }
 
 //Integer converts interface to integer
 func (ef *EntryField) Integer() int {
-	return int(ef.value.(float64))
+	val, err := ef.IntegerValue("")
+	if err != nil {
+		panic(err)
+	}
+
+	return val
 }
 
 //LInteger converts interface to integer
 func (ef *EntryField) LInteger(locale string) int {
-	m := ef.value.(map[string]interface{})
-
-	if val, ok := m[locale]; ok {
-		return int(val.(float64))
+	val, err := ef.IntegerValue(locale)
+	if err != nil {
+		panic(err)
 	}
 
-	panic("no such a locale")
+	return val
 }
 
 //Array converts interface to slice
 func (ef *EntryField) Array() []string {
-	res := []string{}
-
-	switch reflect.TypeOf(ef.value).Kind() {
-	case reflect.Slice:
-		s := reflect.ValueOf(ef.value)
-
-		for i := 0; i < s.Len(); i++ {
-			res = append(res, s.Index(i).Interface().(string))
-		}
+	val, err := ef.SymbolsValue("")
+	if err != nil {
+		return []string{}
 	}
 
-	return res
+	return val
 }
 
 //LArray converts interface to slice
 func (ef *EntryField) LArray(locale string) []string {
-	m := ef.value.(map[string]interface{})
-
-	if val, ok := m[locale]; ok {
-		res := []string{}
-
-		switch reflect.TypeOf(val).Kind() {
-		case reflect.Slice:
-			s := reflect.ValueOf(val)
-
-			for i := 0; i < s.Len(); i++ {
-				res = append(res, s.Index(i).Interface().(string))
-			}
-		}
+	if _, err := ef.Value(locale); err != nil {
+		panic(err)
+	}
 
-		return res
+	val, err := ef.SymbolsValue(locale)
+	if err != nil {
+		return []string{}
 	}
 
-	panic("no such a locale")
+	return val
 }
 
 //LinkID returns link model
 func (ef *EntryField) LinkID() string {
-	m := ef.value.(map[string]interface{})
-	sys := m["sys"].(map[string]interface{})
-	return sys["id"].(string)
+	return ef.LLinkID("")
 }
 
 //LLinkID returns link model
 func (ef *EntryField) LLinkID(locale string) string {
-	m := ef.value.(map[string]interface{})
-
-	if val, ok := m[locale]; ok {
-		m := val.(map[string]interface{})
-		sys := m["sys"].(map[string]interface{})
-		return sys["id"].(string)
+	link, err := ef.LinkValue(locale)
+	if err != nil {
+		panic(err)
 	}
 
-	panic("no such a locale")
+	return link.Sys.ID
 }
 
 //LinkType returns link model
 func (ef *EntryField) LinkType() string {
-	m := ef.value.(map[string]interface{})
-	sys := m["sys"].(map[string]interface{})
-	return sys["linkType"].(string)
+	return ef.LLinkType("")
 }
 
 //LLinkType returns link model
 func (ef *EntryField) LLinkType(locale string) string {
-	m := ef.value.(map[string]interface{})
-
-	if val, ok := m[locale]; ok {
-		m := val.(map[string]interface{})
-		sys := m["sys"].(map[string]interface{})
-		return sys["linkType"].(string)
+	link, err := ef.LinkValue(locale)
+	if err != nil {
+		panic(err)
 	}
 
-	panic("no such a locale")
+	return link.Sys.LinkType
 }
 
 //Asset returns the linked asset
@@ -0,0 +1,237 @@
+package contentful
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var entryFieldsPayload = `{
+	"sys": {"id": "cat"},
+	"fields": {
+		"name": {"en-US": "Nyan Cat", "de-DE": "Nyan Katze"},
+		"lives": {"en-US": 9},
+		"weight": {"en-US": 4.2},
+		"grumpy": {"en-US": false},
+		"birthday": {"en-US": "2011-04-02T00:00+02:00"},
+		"found": {"en-US": "2011-04-02"},
+		"home": {"en-US": {"lat": 52.52, "lon": 13.4}},
+		"meta": {"en-US": {"color": "rainbow"}},
+		"bio": {"en-US": {"nodeType": "document", "data": {}, "content": []}},
+		"tags": {"en-US": ["pop", "tart"]},
+		"bestFriend": {"en-US": {"sys": {"type": "Link", "linkType": "Entry", "id": "happycat"}}},
+		"photos": {"en-US": [{"sys": {"type": "Link", "linkType": "Asset", "id": "nyancat"}}]}
+	}
+}`
+
+func entryFieldsFromPayload(t *testing.T) (*Entry, *ContentType) {
+	var entry Entry
+	if err := json.Unmarshal([]byte(entryFieldsPayload), &entry); err != nil {
+		t.Fatal(err)
+	}
+
+	ct := &ContentType{
+		Fields: []*Field{
+			{ID: "name", Type: FieldTypeSymbol},
+			{ID: "lives", Type: FieldTypeInteger},
+			{ID: "weight", Type: FieldTypeNumber},
+			{ID: "grumpy", Type: FieldTypeBoolean},
+			{ID: "birthday", Type: FieldTypeDate},
+			{ID: "found", Type: FieldTypeDate},
+			{ID: "home", Type: FieldTypeLocation},
+			{ID: "meta", Type: FieldTypeObject},
+			{ID: "bio", Type: FieldTypeRichText},
+			{ID: "tags", Type: FieldTypeArray, Items: &FieldTypeArrayItem{Type: FieldTypeSymbol}},
+			{ID: "bestFriend", Type: FieldTypeLink, LinkType: "Entry"},
+			{ID: "photos", Type: FieldTypeArray, Items: &FieldTypeArrayItem{Type: FieldTypeLink, LinkType: "Asset"}},
+		},
+	}
+
+	return &entry, ct
+}
+
+func TestEntryFieldTypedValues(t *testing.T) {
+	assert := assert.New(t)
+
+	entry, ct := entryFieldsFromPayload(t)
+	field := func(id string) *EntryField {
+		return NewEntryField(entry, ct.Field(id), id)
+	}
+
+	name, err := field("name").StringValue("de-DE")
+	assert.Nil(err)
+	assert.Equal("Nyan Katze", name)
+
+	lives, err := field("lives").IntegerValue("en-US")
+	assert.Nil(err)
+	assert.Equal(9, lives)
+
+	weight, err := field("weight").NumberValue("en-US")
+	assert.Nil(err)
+	assert.Equal(4.2, weight)
+
+	grumpy, err := field("grumpy").BooleanValue("en-US")
+	assert.Nil(err)
+	assert.False(grumpy)
+
+	birthday, err := field("birthday").DateValue("en-US")
+	assert.Nil(err)
+	assert.Equal("2011-04-01T22:00:00Z", birthday.UTC().Format(time.RFC3339))
+
+	found, err := field("found").DateValue("en-US")
+	assert.Nil(err)
+	assert.Equal(time.Date(2011, 4, 2, 0, 0, 0, 0, time.UTC), found)
+
+	home, err := field("home").LocationValue("en-US")
+	assert.Nil(err)
+	assert.Equal(&Location{Lat: 52.52, Lon: 13.4}, home)
+
+	meta, err := field("meta").ObjectValue("en-US")
+	assert.Nil(err)
+	assert.Equal("rainbow", meta["color"])
+
+	bio, err := field("bio").RichTextValue("en-US")
+	assert.Nil(err)
+	assert.Equal("document", bio["nodeType"])
+
+	tags, err := field("tags").SymbolsValue("en-US")
+	assert.Nil(err)
+	assert.Equal([]string{"pop", "tart"}, tags)
+
+	friend, err := field("bestFriend").LinkValue("en-US")
+	assert.Nil(err)
+	assert.Equal(NewLink("Entry", "happycat"), friend)
+
+	photos, err := field("photos").LinksValue("en-US")
+	assert.Nil(err)
+	assert.Equal([]*Link{NewLink("Asset", "nyancat")}, photos)
+}
+
+func TestEntryFieldErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	entry, ct := entryFieldsFromPayload(t)
+	field := func(id string) *EntryField {
+		return NewEntryField(entry, ct.Field(id), id)
+	}
+
+	// missing locale
+	_, err := field("lives").IntegerValue("de-DE")
+	localeErr, ok := err.(FieldLocaleError)
+	assert.True(ok)
+	assert.Equal("lives", localeErr.FieldID)
+	assert.Equal("de-DE", localeErr.Locale)
+
+	// unknown field
+	_, err = field("unknown").StringValue("en-US")
+	_, ok = err.(FieldLocaleError)
+	assert.True(ok)
+
+	// the definition does not match
+	_, err = field("lives").StringValue("en-US")
+	typeErr, ok := err.(FieldTypeError)
+	assert.True(ok)
+	assert.Equal(FieldTypeInteger, typeErr.Actual)
+
+	_, err = field("photos").SymbolsValue("en-US")
+	_, ok = err.(FieldTypeError)
+	assert.True(ok)
+
+	// without a definition the value itself is checked
+	_, err = NewEntryField(entry, nil, "weight").IntegerValue("en-US")
+	_, ok = err.(FieldTypeError)
+	assert.True(ok)
+
+	_, err = NewEntryField(entry, nil, "meta").RichTextValue("en-US")
+	_, ok = err.(FieldTypeError)
+	assert.True(ok)
+
+	_, err = NewEntryField(entry, nil, "name").DateValue("en-US")
+	_, ok = err.(FieldTypeError)
+	assert.True(ok)
+}
+
+func TestEntryFieldHandBuiltValues(t *testing.T) {
+	assert := assert.New(t)
+
+	entry := &Entry{
+		Fields: map[string]interface{}{
+			"title": map[string]string{"en-US": "Hello"},
+			"count": map[string]int{"en-US": 3},
+			"tags":  []string{"a", "b"},
+		},
+	}
+
+	title, err := NewEntryField(entry, nil, "title").StringValue("en-US")
+	assert.Nil(err)
+	assert.Equal("Hello", title)
+
+	count, err := NewEntryField(entry, nil, "count").IntegerValue("en-US")
+	assert.Nil(err)
+	assert.Equal(3, count)
+
+	assert.Equal([]string{"a", "b"}, NewEntryField(entry, nil, "tags").Array())
+}
+
+func TestEntryFieldLegacyAccessors(t *testing.T) {
+	assert := assert.New(t)
+
+	entry, ct := entryFieldsFromPayload(t)
+	field := func(id string) *EntryField {
+		return NewEntryField(entry, ct.Field(id), id)
+	}
+
+	assert.Equal("Nyan Cat", field("name").LString("en-US"))
+	assert.Equal(9, field("lives").LInteger("en-US"))
+	assert.Equal([]string{"pop", "tart"}, field("tags").LArray("en-US"))
+	assert.Equal("happycat", field("bestFriend").LLinkID("en-US"))
+	assert.Equal("Entry", field("bestFriend").LLinkType("en-US"))
+
+	assert.Panics(func() {
+		field("name").LString("fr-FR")
+	})
+}
+
+func TestEntriesServiceGetEntryKey(t *testing.T) {
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("/spaces/"+spaceID+"/content_types/cat", r.URL.Path)
+
+		fmt.Fprintln(w, `{"sys": {"id": "cat"}, "fields": [{"id": "lives", "name": "Lives", "type": "Integer"}]}`)
+	})
+
+	// test server
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	entry := &Entry{
+		Sys: &Sys{
+			Space:       &Space{Sys: &Sys{ID: spaceID}},
+			ContentType: &ContentType{Sys: &Sys{ID: "cat"}},
+		},
+		Fields: map[string]interface{}{
+			"lives": map[string]interface{}{"en-US": float64(9)},
+		},
+	}
+
+	field, err := cma.Entries.GetEntryKey(entry, "lives")
+	assert.Nil(err)
+	assert.Equal(FieldTypeInteger, field.Type())
+
+	_, err = field.StringValue("en-US")
+	assert.NotNil(err)
+
+	lives, err := field.IntegerValue("en-US")
+	assert.Nil(err)
+	assert.Equal(9, lives)
+}
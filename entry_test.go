@@ -36,7 +36,7 @@ func ExampleEntriesService_Upsert_create() {
 	}
 }
 
-func ExampleEntryService_Upsert_update() {
+func ExampleEntriesService_Upsert_update() {
 	cma := NewCMA("cma-token")
 
 	entry, err := cma.Entries.Get("space-id", "entry-id")
@@ -103,4 +103,48 @@ func TestEntrySaveForCreate(t *testing.T) {
 	err = cma.Entries.Upsert("id1", entry)
 	assert.Nil(err)
 	assert.Equal("foocat", entry.Sys.ID)
-}
\ No newline at end of file
+}
+func TestEntryDecode(t *testing.T) {
+	assert := assert.New(t)
+
+	type cat struct {
+		Sys        *Sys     `json:"sys"`
+		Name       string   `json:"name"`
+		Lives      int      `json:"lives"`
+		Likes      []string `json:"likes"`
+		BestFriend *Link    `json:"bestFriend"`
+	}
+
+	entry := &Entry{
+		Sys: &Sys{ID: "nyancat"},
+		Fields: map[string]interface{}{
+			"name":       map[string]interface{}{"en-US": "Nyan Cat", "de-DE": "Nyan Katze"},
+			"lives":      map[string]interface{}{"en-US": 1337.0},
+			"likes":      map[string]interface{}{"en-US": []interface{}{"rainbows", "fish"}},
+			"bestFriend": map[string]interface{}{"en-US": NewLink("Entry", "happycat")},
+		},
+	}
+
+	var v cat
+	assert.Nil(entry.Decode("en-US", &v))
+	assert.Equal("nyancat", v.Sys.ID)
+	assert.Equal("Nyan Cat", v.Name)
+	assert.Equal(1337, v.Lives)
+	assert.Equal([]string{"rainbows", "fish"}, v.Likes)
+	assert.Equal("happycat", v.BestFriend.Sys.ID)
+
+	// fields without a value for the locale are left out
+	v = cat{}
+	assert.Nil(entry.Decode("de-DE", &v))
+	assert.Equal("Nyan Katze", v.Name)
+	assert.Equal(0, v.Lives)
+
+	// single locale responses are read as is
+	single := &Entry{
+		Sys:    &Sys{ID: "happycat", Locale: "en-US"},
+		Fields: map[string]interface{}{"name": "Happy Cat"},
+	}
+	v = cat{}
+	assert.Nil(single.Decode("de-DE", &v))
+	assert.Equal("Happy Cat", v.Name)
+}
@@ -0,0 +1,644 @@
+package contentful
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Entry validation rules, named like the validations reported by the api
+const (
+	EntryValidationRequired             = "required"
+	EntryValidationUnknown              = "unknown"
+	EntryValidationType                 = "type"
+	EntryValidationSize                 = "size"
+	EntryValidationRange                = "range"
+	EntryValidationRegexp               = "regexp"
+	EntryValidationProhibitRegexp       = "prohibitRegexp"
+	EntryValidationIn                   = "in"
+	EntryValidationDateRange            = "dateRange"
+	EntryValidationLinkContentType      = "linkContentType"
+	EntryValidationLinkMimetypeGroup    = "linkMimetypeGroup"
+	EntryValidationAssetImageDimensions = "assetImageDimensions"
+	EntryValidationAssetFileSize        = "assetFileSize"
+	EntryValidationNotResolvable        = "notResolvable"
+)
+
+// EntryValidationError describes a field value which violates the definition of its content type
+type EntryValidationError struct {
+	FieldID string
+	Locale  string
+	// Rule is the violated validation, one of the EntryValidation constants
+	// or a rich text validation such as enabledNodeTypes
+	Rule string
+	// Path of the array item or rich text node inside the value, empty for the value itself
+	Path    []interface{}
+	Message string
+}
+
+func (e EntryValidationError) Error() string {
+	location := e.FieldID
+	if e.Locale != "" {
+		location += "." + e.Locale
+	}
+
+	for _, segment := range e.Path {
+		location += "." + fmt.Sprint(segment)
+	}
+
+	return fmt.Sprintf("%s fails %s validation: %s", location, e.Rule, e.Message)
+}
+
+// EntryValidator checks entries against the definition of their content type before they are sent to the api.
+// Link content types and asset files can only be checked with the linked entities, they are skipped
+// unless the resolvers are set. Unique validations need the other entries and are never checked.
+type EntryValidator struct {
+	// ResolveEntry returns the linked entry of the given id
+	ResolveEntry func(id string) (*Entry, error)
+
+	// ResolveAsset returns the linked asset of the given id
+	ResolveAsset func(id string) (*Asset, error)
+}
+
+// Validate checks an entry against its content type without resolving links.
+// The first locale is the default locale, fields which are not localized are only checked in it.
+func Validate(entry *Entry, ct *ContentType, locales []string) []EntryValidationError {
+	return (&EntryValidator{}).Validate(entry, ct, locales)
+}
+
+// entryValidation holds the state of a single validation run
+type entryValidation struct {
+	validator *EntryValidator
+	entries   map[string]*Entry
+	assets    map[string]*Asset
+	failed    map[string]error
+	reported  map[string]bool
+	errors    []EntryValidationError
+}
+
+// Validate checks an entry against its content type and returns every violation found.
+// The first locale is the default locale, fields which are not localized are only checked in it.
+func (v *EntryValidator) Validate(entry *Entry, ct *ContentType, locales []string) []EntryValidationError {
+	run := &entryValidation{
+		validator: v,
+		entries:   map[string]*Entry{},
+		assets:    map[string]*Asset{},
+		failed:    map[string]error{},
+		reported:  map[string]bool{},
+		errors:    []EntryValidationError{},
+	}
+
+	defined := map[string]bool{}
+	for _, field := range ct.Fields {
+		defined[field.ID] = true
+
+		fieldLocales := locales
+		if !field.Localized && len(locales) > 0 {
+			fieldLocales = locales[:1]
+		}
+
+		for _, locale := range fieldLocales {
+			run.field(entry, field, locale)
+		}
+	}
+
+	unknown := []string{}
+	for id := range entry.Fields {
+		if !defined[id] {
+			unknown = append(unknown, id)
+		}
+	}
+	sort.Strings(unknown)
+
+	for _, id := range unknown {
+		run.fail(id, "", EntryValidationUnknown, nil, "", "the field is not defined by content type "+ct.Name)
+	}
+
+	return run.errors
+}
+
+func (run *entryValidation) fail(fieldID, locale, rule string, path []interface{}, message, fallback string) {
+	if message == "" {
+		message = fallback
+	}
+
+	if path == nil {
+		path = []interface{}{}
+	}
+
+	run.errors = append(run.errors, EntryValidationError{
+		FieldID: fieldID,
+		Locale:  locale,
+		Rule:    rule,
+		Path:    path,
+		Message: message,
+	})
+}
+
+func (run *entryValidation) field(entry *Entry, field *Field, locale string) {
+	ef := NewEntryField(entry, field, field.ID)
+
+	if _, err := ef.Value(locale); err != nil {
+		if field.Required {
+			run.fail(field.ID, locale, EntryValidationRequired, nil, "", "the field is required")
+		}
+		return
+	}
+
+	value, err := typedFieldValue(ef, field, locale)
+	if err != nil {
+		run.fail(field.ID, locale, EntryValidationType, nil, "", err.Error())
+		return
+	}
+
+	run.value(field.ID, locale, nil, value, field.Validations)
+
+	if field.Type == FieldTypeArray && field.Items != nil {
+		items, _ := ef.ArrayValue(locale)
+		for i, item := range items {
+			path := []interface{}{i}
+			if field.Items.Type == FieldTypeLink {
+				link, _ := toLink(item)
+				run.value(field.ID, locale, path, link, field.Items.Validations)
+				continue
+			}
+
+			run.value(field.ID, locale, path, item, field.Items.Validations)
+		}
+	}
+}
+
+// typedFieldValue reads the value with the accessor of the field type, failing when the value doesn't match it
+func typedFieldValue(ef *EntryField, field *Field, locale string) (interface{}, error) {
+	switch field.Type {
+	case FieldTypeSymbol, FieldTypeText:
+		return ef.StringValue(locale)
+	case FieldTypeInteger:
+		n, err := ef.IntegerValue(locale)
+		return float64(n), err
+	case FieldTypeNumber:
+		return ef.NumberValue(locale)
+	case FieldTypeBoolean:
+		return ef.BooleanValue(locale)
+	case FieldTypeDate:
+		return ef.DateValue(locale)
+	case FieldTypeLocation:
+		return ef.LocationValue(locale)
+	case FieldTypeObject:
+		return ef.ObjectValue(locale)
+	case FieldTypeRichText:
+		return ef.RichTextDocument(locale)
+	case FieldTypeLink:
+		link, err := ef.LinkValue(locale)
+		if err == nil && field.LinkType != "" && link.Sys.LinkType != field.LinkType {
+			return nil, FieldTypeError{FieldID: field.ID, Expected: "Link to " + field.LinkType, Actual: "Link to " + link.Sys.LinkType}
+		}
+		return link, err
+	case FieldTypeArray:
+		if field.Items == nil {
+			return ef.ArrayValue(locale)
+		}
+
+		switch field.Items.Type {
+		case FieldTypeSymbol:
+			return ef.SymbolsValue(locale)
+		case FieldTypeLink:
+			links, err := ef.LinksValue(locale)
+			for _, link := range links {
+				if field.Items.LinkType != "" && link.Sys.LinkType != field.Items.LinkType {
+					return nil, FieldTypeError{FieldID: field.ID, Expected: "Array of Link to " + field.Items.LinkType, Actual: "Link to " + link.Sys.LinkType}
+				}
+			}
+			return links, err
+		}
+
+		return ef.ArrayValue(locale)
+	}
+
+	return ef.Value(locale)
+}
+
+// dereferenceValidation returns the validation a pointer points to, so that
+// validations like &FieldValidationRange{} are applied like their values
+func dereferenceValidation(validation FieldValidation) FieldValidation {
+	v := reflect.ValueOf(validation)
+	if v.Kind() == reflect.Ptr && !v.IsNil() {
+		return v.Elem().Interface()
+	}
+
+	return validation
+}
+
+// value applies the validations to a field value or an array item
+func (run *entryValidation) value(fieldID, locale string, path []interface{}, value interface{}, validations []FieldValidation) {
+	fail := func(rule, message, fallback string, args ...interface{}) {
+		run.fail(fieldID, locale, rule, path, message, fmt.Sprintf(fallback, args...))
+	}
+
+	for _, validation := range validations {
+		switch validation := dereferenceValidation(validation).(type) {
+		case FieldValidationSize:
+			if size, ok := valueSize(value); ok && !inMinMax(float64(size), validation.Size) {
+				fail(EntryValidationSize, validation.ErrorMessage, "size %d is not %s", size, describeMinMax(validation.Size))
+			}
+
+		case FieldValidationRange:
+			if n, ok := toFloat(value); ok && !inMinMax(n, validation.Range) {
+				fail(EntryValidationRange, validation.ErrorMessage, "%v is not %s", n, describeMinMax(validation.Range))
+			}
+
+		case FieldValidationRegex:
+			if s, ok := value.(string); ok && validation.Regex != nil {
+				re, err := compileValidationRegex(validation.Regex)
+				if err != nil {
+					fail(EntryValidationRegexp, "", "invalid pattern %q: %s", validation.Regex.Pattern, err)
+				} else if !re.MatchString(s) {
+					fail(EntryValidationRegexp, validation.ErrorMessage, "%q does not match %q", s, validation.Regex.Pattern)
+				}
+			}
+
+		case FieldValidationProhibitRegex:
+			if s, ok := value.(string); ok && validation.Regex != nil {
+				re, err := compileValidationRegex(validation.Regex)
+				if err != nil {
+					fail(EntryValidationProhibitRegexp, "", "invalid pattern %q: %s", validation.Regex.Pattern, err)
+				} else if re.MatchString(s) {
+					fail(EntryValidationProhibitRegexp, validation.ErrorMessage, "%q matches the prohibited %q", s, validation.Regex.Pattern)
+				}
+			}
+
+		case FieldValidationPredefinedValues:
+			if !predefined(value, validation.In) {
+				fail(EntryValidationIn, validation.ErrorMessage, "%v is not one of %v", value, validation.In)
+			}
+
+		case FieldValidationDate:
+			if t, ok := value.(time.Time); ok && validation.Range != nil {
+				if (!validation.Range.Min.IsZero() && t.Before(validation.Range.Min)) ||
+					(!validation.Range.Max.IsZero() && t.After(validation.Range.Max)) {
+					fail(EntryValidationDateRange, validation.ErrorMessage, "%s is not %s", t.Format(FieldValidationDateLayout), describeDateMinMax(validation.Range))
+				}
+			}
+
+		case FieldValidationLink:
+			if link, ok := value.(*Link); ok && link != nil && link.Sys.LinkType == "Entry" {
+				run.linkContentType(fieldID, locale, path, link, validation)
+			}
+
+		case FieldValidationMimeType, FieldValidationDimension, FieldValidationFileSize:
+			if link, ok := value.(*Link); ok && link != nil && link.Sys.LinkType == "Asset" {
+				run.asset(fieldID, locale, path, link, validation)
+			}
+		}
+	}
+
+	if doc, ok := value.(*RichTextNode); ok {
+		run.richText(fieldID, locale, doc, validations)
+	}
+}
+
+// valueSize is the length of strings, arrays and rich text plain text, or the number of object properties
+func valueSize(value interface{}) (int, bool) {
+	switch value := value.(type) {
+	case string:
+		return utf8.RuneCountInString(value), true
+	case []string:
+		return len(value), true
+	case []*Link:
+		return len(value), true
+	case []interface{}:
+		return len(value), true
+	case map[string]interface{}:
+		return len(value), true
+	case *RichTextNode:
+		return utf8.RuneCountInString(value.PlainText()), true
+	}
+
+	return 0, false
+}
+
+// compileValidationRegex translates the javascript flags of the pattern, the global flag has no meaning here
+func compileValidationRegex(r *Regex) (*regexp.Regexp, error) {
+	flags := ""
+	for _, flag := range r.Flags {
+		if flag == 'i' || flag == 'm' || flag == 's' {
+			flags += string(flag)
+		}
+	}
+
+	pattern := r.Pattern
+	if flags != "" {
+		pattern = "(?" + flags + ")" + pattern
+	}
+
+	return regexp.Compile(pattern)
+}
+
+func predefined(value interface{}, in []interface{}) bool {
+	switch value := value.(type) {
+	case *Link, []*Link:
+		return true
+	case []string:
+		for _, item := range value {
+			if !predefined(item, in) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		for _, item := range value {
+			if !predefined(item, in) {
+				return false
+			}
+		}
+		return true
+	}
+
+	for _, allowed := range in {
+		if a, ok := toFloat(allowed); ok {
+			if n, ok := toFloat(value); ok && a == n {
+				return true
+			}
+			continue
+		}
+
+		if allowed == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (run *entryValidation) resolveEntry(id string) (*Entry, error) {
+	if entry, ok := run.entries[id]; ok {
+		return entry, nil
+	}
+
+	if err, ok := run.failed["Entry:"+id]; ok {
+		return nil, err
+	}
+
+	entry, err := run.validator.ResolveEntry(id)
+	if err == nil && entry == nil {
+		err = fmt.Errorf("entry %s not found", id)
+	}
+
+	if err != nil {
+		run.failed["Entry:"+id] = err
+		return nil, err
+	}
+
+	run.entries[id] = entry
+	return entry, nil
+}
+
+func (run *entryValidation) resolveAsset(id string) (*Asset, error) {
+	if asset, ok := run.assets[id]; ok {
+		return asset, nil
+	}
+
+	if err, ok := run.failed["Asset:"+id]; ok {
+		return nil, err
+	}
+
+	asset, err := run.validator.ResolveAsset(id)
+	if err == nil && asset == nil {
+		err = fmt.Errorf("asset %s not found", id)
+	}
+
+	if err != nil {
+		run.failed["Asset:"+id] = err
+		return nil, err
+	}
+
+	run.assets[id] = asset
+	return asset, nil
+}
+
+// unresolvable reports a broken link once, however many validations need the linked entity
+func (run *entryValidation) unresolvable(fieldID, locale string, path []interface{}, link *Link, err error) {
+	key := fmt.Sprint(fieldID, locale, path)
+	if run.reported[key] {
+		return
+	}
+
+	run.reported[key] = true
+	run.fail(fieldID, locale, EntryValidationNotResolvable, path, "",
+		fmt.Sprintf("%s %s can not be resolved: %s", strings.ToLower(link.Sys.LinkType), link.Sys.ID, err))
+}
+
+func (run *entryValidation) linkContentType(fieldID, locale string, path []interface{}, link *Link, validation FieldValidationLink) {
+	if run.validator.ResolveEntry == nil || len(validation.LinkContentType) == 0 {
+		return
+	}
+
+	entry, err := run.resolveEntry(link.Sys.ID)
+	if err != nil {
+		run.unresolvable(fieldID, locale, path, link, err)
+		return
+	}
+
+	ctID := ""
+	if entry.Sys != nil && entry.Sys.ContentType != nil && entry.Sys.ContentType.Sys != nil {
+		ctID = entry.Sys.ContentType.Sys.ID
+	}
+
+	for _, allowed := range validation.LinkContentType {
+		if allowed == ctID {
+			return
+		}
+	}
+
+	run.fail(fieldID, locale, EntryValidationLinkContentType, path, validation.ErrorMessage,
+		fmt.Sprintf("entry %s is a %s, not one of %s", link.Sys.ID, ctID, strings.Join(validation.LinkContentType, ", ")))
+}
+
+// mimeTypeGroups maps content types to the mime type groups of the web app,
+// types of no group are attachments
+var mimeTypeGroups = map[string]string{
+	"text/plain":         MimeTypePlainText,
+	"application/rtf":    MimeTypeRichText,
+	"text/rtf":           MimeTypeRichText,
+	"application/msword": MimeTypeRichText,
+	"application/vnd.oasis.opendocument.text":                                   MimeTypeRichText,
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document":   MimeTypeRichText,
+	"application/vnd.ms-powerpoint":                                             MimeTypePresentation,
+	"application/vnd.oasis.opendocument.presentation":                           MimeTypePresentation,
+	"application/vnd.openxmlformats-officedocument.presentationml.presentation": MimeTypePresentation,
+	"application/vnd.ms-excel":                                                  MimeTypeSpreadSheet,
+	"application/vnd.oasis.opendocument.spreadsheet":                            MimeTypeSpreadSheet,
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":         MimeTypeSpreadSheet,
+	"text/csv":                     MimeTypeSpreadSheet,
+	"application/pdf":              MimeTypePDF,
+	"application/zip":              MimeTypeArchive,
+	"application/x-zip-compressed": MimeTypeArchive,
+	"application/gzip":             MimeTypeArchive,
+	"application/x-gzip":           MimeTypeArchive,
+	"application/x-tar":            MimeTypeArchive,
+	"application/x-rar-compressed": MimeTypeArchive,
+	"application/x-7z-compressed":  MimeTypeArchive,
+	"application/json":             MimeTypeCode,
+	"application/javascript":       MimeTypeCode,
+	"text/javascript":              MimeTypeCode,
+	"text/css":                     MimeTypeCode,
+	"text/html":                    MimeTypeMarkup,
+	"text/xml":                     MimeTypeMarkup,
+	"application/xml":              MimeTypeMarkup,
+	"application/xhtml+xml":        MimeTypeMarkup,
+}
+
+// MimeTypeGroup returns the mime type group of a file content type
+func MimeTypeGroup(contentType string) string {
+	contentType = strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+
+	if group, ok := mimeTypeGroups[contentType]; ok {
+		return group
+	}
+
+	for _, group := range []string{MimeTypeImage, MimeTypeAudio, MimeTypeVideo} {
+		if strings.HasPrefix(contentType, group+"/") {
+			return group
+		}
+	}
+
+	return MimeTypeAttachment
+}
+
+func (run *entryValidation) asset(fieldID, locale string, path []interface{}, link *Link, validation FieldValidation) {
+	if run.validator.ResolveAsset == nil {
+		return
+	}
+
+	asset, err := run.resolveAsset(link.Sys.ID)
+	if err != nil {
+		run.unresolvable(fieldID, locale, path, link, err)
+		return
+	}
+
+	// assets are linked as a whole, check the file of the same locale or else of any locale
+	file := asset.File(locale)
+	for _, l := range asset.Locales() {
+		if file != nil {
+			break
+		}
+		file = asset.File(l)
+	}
+
+	if file == nil {
+		return
+	}
+
+	switch validation := validation.(type) {
+	case FieldValidationMimeType:
+		group := MimeTypeGroup(file.ContentType)
+		for _, allowed := range validation.MimeTypes {
+			if allowed == group {
+				return
+			}
+		}
+
+		run.fail(fieldID, locale, EntryValidationLinkMimetypeGroup, path, validation.ErrorMessage,
+			fmt.Sprintf("asset %s is %s, not one of %s", link.Sys.ID, group, strings.Join(validation.MimeTypes, ", ")))
+
+	case FieldValidationDimension:
+		if file.Detail == nil || file.Detail.Image == nil {
+			return
+		}
+
+		image := file.Detail.Image
+		if !inMinMax(float64(image.Width), validation.Width) || !inMinMax(float64(image.Height), validation.Height) {
+			run.fail(fieldID, locale, EntryValidationAssetImageDimensions, path, validation.ErrorMessage,
+				fmt.Sprintf("asset %s is %dx%d pixels", link.Sys.ID, image.Width, image.Height))
+		}
+
+	case FieldValidationFileSize:
+		if file.Detail == nil {
+			return
+		}
+
+		if !inMinMax(float64(file.Detail.Size), validation.Size) {
+			run.fail(fieldID, locale, EntryValidationAssetFileSize, path, validation.ErrorMessage,
+				fmt.Sprintf("asset %s is %d bytes, not %s", link.Sys.ID, file.Detail.Size, describeMinMax(validation.Size)))
+		}
+	}
+}
+
+// richText applies the rich text validations, the node validations check embedded and linked entities
+func (run *entryValidation) richText(fieldID, locale string, doc *RichTextNode, validations []FieldValidation) {
+	for _, err := range ValidateRichText(doc, validations) {
+		run.fail(fieldID, locale, err.Validation, err.Path, err.Message, "")
+	}
+
+	nodes := map[string][]FieldValidation{}
+	for _, validation := range validations {
+		switch validation := validation.(type) {
+		case FieldValidationNodes:
+			nodes = validation.Nodes
+		case *FieldValidationNodes:
+			nodes = validation.Nodes
+		}
+	}
+
+	var walk func(node *RichTextNode, path []interface{})
+	walk = func(node *RichTextNode, path []interface{}) {
+		if node.Data != nil && node.Data.Target != nil && node.Data.Target.Sys != nil {
+			for _, validation := range nodes[node.NodeType] {
+				switch dereferenceValidation(validation).(type) {
+				case FieldValidationLink, FieldValidationMimeType, FieldValidationDimension, FieldValidationFileSize:
+					run.value(fieldID, locale, path, node.Data.Target, []FieldValidation{validation})
+				}
+			}
+		}
+
+		for i, child := range node.Content {
+			walk(child, append(append([]interface{}{}, path...), "content", i))
+		}
+	}
+
+	walk(doc, []interface{}{})
+}
+
+// Validate checks an entry against its content type, read through the client content type cache.
+// Linked entries and assets are fetched to check their content types and files.
+// Without locales the locales of the space are checked, the default locale first.
+func (service *EntriesService) Validate(spaceID string, entry *Entry, locales ...string) ([]EntryValidationError, error) {
+	if entry.Sys == nil || entry.Sys.ContentType == nil || entry.Sys.ContentType.Sys == nil {
+		return nil, fmt.Errorf("entry requires a content type")
+	}
+
+	ct, err := service.c.ContentTypes.GetCached(spaceID, entry.Sys.ContentType.Sys.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(locales) == 0 {
+		col, err := service.c.Locales.List(spaceID).Next()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, locale := range col.ToLocale() {
+			if locale.Default {
+				locales = append([]string{locale.Code}, locales...)
+			} else {
+				locales = append(locales, locale.Code)
+			}
+		}
+	}
+
+	validator := &EntryValidator{
+		ResolveEntry: func(id string) (*Entry, error) {
+			return service.Get(spaceID, id)
+		},
+		ResolveAsset: func(id string) (*Asset, error) {
+			return service.c.Assets.Get(spaceID, id)
+		},
+	}
+
+	return validator.Validate(entry, ct, locales), nil
+}
@@ -0,0 +1,411 @@
+package contentful
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func catContentType() *ContentType {
+	return &ContentType{
+		Sys:  &Sys{ID: "cat"},
+		Name: "Cat",
+		Fields: []*Field{
+			{ID: "name", Type: FieldTypeSymbol, Required: true, Localized: true, Validations: []FieldValidation{
+				FieldValidationSize{Size: NewMinMax(2, 10)},
+				FieldValidationRegex{Regex: &Regex{Pattern: "^[a-z ]+$", Flags: "i"}, ErrorMessage: "letters only"},
+				FieldValidationProhibitRegex{Regex: &Regex{Pattern: "dog"}},
+			}},
+			{ID: "lives", Type: FieldTypeInteger, Validations: []FieldValidation{
+				FieldValidationRange{Range: NewMinMax(1, 9)},
+			}},
+			{ID: "color", Type: FieldTypeSymbol, Validations: []FieldValidation{
+				FieldValidationPredefinedValues{In: []interface{}{"black", "white"}},
+			}},
+			{ID: "birthday", Type: FieldTypeDate, Validations: []FieldValidation{
+				FieldValidationDate{Range: &DateMinMax{Min: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)}},
+			}},
+			{ID: "bestFriend", Type: FieldTypeLink, LinkType: "Entry", Validations: []FieldValidation{
+				FieldValidationLink{LinkContentType: []string{"cat"}},
+			}},
+			{ID: "photos", Type: FieldTypeArray, Items: &FieldTypeArrayItem{
+				Type:     FieldTypeLink,
+				LinkType: "Asset",
+				Validations: []FieldValidation{
+					FieldValidationMimeType{MimeTypes: []string{MimeTypeImage}},
+					FieldValidationDimension{Width: NewMax(1000)},
+					FieldValidationFileSize{Size: NewMax(1024)},
+				},
+			}, Validations: []FieldValidation{
+				FieldValidationSize{Size: NewMax(2)},
+			}},
+		},
+	}
+}
+
+func linkValue(linkType, id string) map[string]interface{} {
+	return map[string]interface{}{
+		"sys": map[string]interface{}{"type": "Link", "linkType": linkType, "id": id},
+	}
+}
+
+func assetWithFile(contentType string, size, width int) *Asset {
+	asset := &Asset{Sys: &Sys{ID: "photo"}}
+	asset.SetFile("en-US", &File{
+		ContentType: contentType,
+		Detail:      &FileDetail{Size: size, Image: &FileImage{Width: width, Height: width}},
+	})
+
+	return asset
+}
+
+func TestValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	valid := &Entry{Fields: map[string]interface{}{
+		"name":     map[string]interface{}{"en-US": "Nyan Cat", "de-DE": "Nyan Katze"},
+		"lives":    map[string]interface{}{"en-US": float64(9)},
+		"color":    map[string]interface{}{"en-US": "black"},
+		"birthday": map[string]interface{}{"en-US": "2011-04-02"},
+	}}
+	assert.Equal([]EntryValidationError{}, Validate(valid, catContentType(), []string{"en-US", "de-DE"}))
+
+	invalid := &Entry{Fields: map[string]interface{}{
+		"name":     map[string]interface{}{"en-US": "Hot dog 2"},
+		"lives":    map[string]interface{}{"en-US": float64(10), "de-DE": "viele"},
+		"color":    map[string]interface{}{"en-US": "rainbow"},
+		"birthday": map[string]interface{}{"en-US": "1999-12-31"},
+		"purrs":    map[string]interface{}{"en-US": true},
+	}}
+
+	errs := Validate(invalid, catContentType(), []string{"en-US", "de-DE"})
+
+	rules := []string{}
+	for _, err := range errs {
+		rules = append(rules, err.FieldID+"/"+err.Locale+"/"+err.Rule)
+	}
+	assert.Equal([]string{
+		"name/en-US/regexp",
+		"name/en-US/prohibitRegexp",
+		"name/de-DE/required",
+		"lives/en-US/range",
+		"color/en-US/in",
+		"birthday/en-US/dateRange",
+		"purrs//unknown",
+	}, rules)
+
+	assert.Equal("letters only", errs[0].Message)
+	assert.Equal("lives.en-US fails range validation: 10 is not between 1 and 9", errs[3].Error())
+	assert.Equal("birthday.en-US fails dateRange validation: 1999-12-31T00:00:00 is not on or after 2000-01-01T00:00:00", errs[5].Error())
+
+	// type mismatches skip the validations of the value
+	errs = Validate(&Entry{Fields: map[string]interface{}{
+		"name":       map[string]interface{}{"en-US": 42},
+		"lives":      map[string]interface{}{"en-US": 1.5},
+		"bestFriend": map[string]interface{}{"en-US": linkValue("Asset", "nyancat")},
+	}}, catContentType(), []string{"en-US"})
+
+	assert.Equal(3, len(errs))
+	for _, err := range errs {
+		assert.Equal(EntryValidationType, err.Rule)
+	}
+}
+
+func TestValidateZeroBounds(t *testing.T) {
+	assert := assert.New(t)
+
+	ct := &ContentType{Fields: []*Field{
+		{ID: "tags", Type: FieldTypeArray, Items: &FieldTypeArrayItem{Type: FieldTypeSymbol}, Validations: []FieldValidation{
+			FieldValidationSize{Size: NewMax(0)},
+		}},
+		{ID: "temperature", Type: FieldTypeNumber, Validations: []FieldValidation{
+			FieldValidationRange{Range: NewMin(0)},
+		}},
+		{ID: "debt", Type: FieldTypeNumber, Validations: []FieldValidation{
+			FieldValidationRange{Range: NewMinMax(-100, 0)},
+		}},
+		{ID: "deadline", Type: FieldTypeDate, Validations: []FieldValidation{
+			FieldValidationDate{Range: &DateMinMax{Max: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}},
+		}},
+	}}
+
+	valid := &Entry{Fields: map[string]interface{}{
+		"tags":        map[string]interface{}{"en-US": []interface{}{}},
+		"temperature": map[string]interface{}{"en-US": float64(0)},
+		"debt":        map[string]interface{}{"en-US": float64(0)},
+	}}
+	assert.Equal([]EntryValidationError{}, Validate(valid, ct, []string{"en-US"}))
+
+	invalid := &Entry{Fields: map[string]interface{}{
+		"tags":        map[string]interface{}{"en-US": []interface{}{"cats"}},
+		"temperature": map[string]interface{}{"en-US": float64(-4)},
+		"debt":        map[string]interface{}{"en-US": float64(5)},
+		"deadline":    map[string]interface{}{"en-US": "2020-01-02"},
+	}}
+
+	errs := Validate(invalid, ct, []string{"en-US"})
+	assert.Equal(4, len(errs))
+	assert.Equal("tags.en-US fails size validation: size 1 is not at most 0", errs[0].Error())
+	assert.Equal("temperature.en-US fails range validation: -4 is not at least 0", errs[1].Error())
+	assert.Equal("debt.en-US fails range validation: 5 is not between -100 and 0", errs[2].Error())
+	assert.Equal("deadline.en-US fails dateRange validation: 2020-01-02T00:00:00 is not on or before 2020-01-01T00:00:00", errs[3].Error())
+}
+
+func TestEntryValidatorResolvers(t *testing.T) {
+	assert := assert.New(t)
+
+	entries := map[string]*Entry{
+		"happycat": {Sys: &Sys{ContentType: &ContentType{Sys: &Sys{ID: "cat"}}}},
+		"doge":     {Sys: &Sys{ContentType: &ContentType{Sys: &Sys{ID: "dog"}}}},
+	}
+	assets := map[string]*Asset{
+		"small": assetWithFile("image/png", 512, 100),
+		"huge":  assetWithFile("image/jpeg", 4096, 2000),
+		"pdf":   assetWithFile("application/pdf", 512, 0),
+	}
+
+	resolved := 0
+	validator := &EntryValidator{
+		ResolveEntry: func(id string) (*Entry, error) {
+			resolved++
+			if entry, ok := entries[id]; ok {
+				return entry, nil
+			}
+			return nil, fmt.Errorf("not found")
+		},
+		ResolveAsset: func(id string) (*Asset, error) {
+			resolved++
+			return assets[id], nil
+		},
+	}
+
+	entry := &Entry{Fields: map[string]interface{}{
+		"name":       map[string]interface{}{"en-US": "Nyan Cat"},
+		"bestFriend": map[string]interface{}{"en-US": linkValue("Entry", "happycat")},
+		"photos": map[string]interface{}{"en-US": []interface{}{
+			linkValue("Asset", "small"),
+			linkValue("Asset", "small"),
+		}},
+	}}
+	assert.Equal([]EntryValidationError{}, validator.Validate(entry, catContentType(), []string{"en-US"}))
+	assert.Equal(2, resolved)
+
+	entry.Fields["bestFriend"] = map[string]interface{}{"en-US": linkValue("Entry", "doge")}
+	entry.Fields["photos"] = map[string]interface{}{"en-US": []interface{}{
+		linkValue("Asset", "huge"),
+		linkValue("Asset", "pdf"),
+		linkValue("Asset", "gone"),
+	}}
+
+	errs := validator.Validate(entry, catContentType(), []string{"en-US"})
+
+	rules := []string{}
+	for _, err := range errs {
+		rules = append(rules, fmt.Sprintf("%s%v/%s", err.FieldID, err.Path, err.Rule))
+	}
+	assert.Equal([]string{
+		"bestFriend[]/linkContentType",
+		"photos[]/size",
+		"photos[0]/assetImageDimensions",
+		"photos[0]/assetFileSize",
+		"photos[1]/linkMimetypeGroup",
+		"photos[2]/notResolvable",
+	}, rules)
+
+	entry.Fields["bestFriend"] = map[string]interface{}{"en-US": linkValue("Entry", "grumpycat")}
+	errs = validator.Validate(entry, catContentType(), []string{"en-US"})
+	assert.Equal(EntryValidationNotResolvable, errs[0].Rule)
+}
+
+func TestValidatePointerValidations(t *testing.T) {
+	assert := assert.New(t)
+
+	ct := &ContentType{Fields: []*Field{
+		{ID: "name", Type: FieldTypeSymbol, Validations: []FieldValidation{
+			&FieldValidationSize{Size: NewMinMax(2, 10)},
+			&FieldValidationRegex{Regex: &Regex{Pattern: "^[a-z ]+$", Flags: "i"}},
+			&FieldValidationProhibitRegex{Regex: &Regex{Pattern: "dog"}},
+		}},
+		{ID: "lives", Type: FieldTypeInteger, Validations: []FieldValidation{
+			&FieldValidationRange{Range: NewMinMax(1, 9)},
+		}},
+		{ID: "color", Type: FieldTypeSymbol, Validations: []FieldValidation{
+			&FieldValidationPredefinedValues{In: []interface{}{"black", "white"}},
+		}},
+		{ID: "birthday", Type: FieldTypeDate, Validations: []FieldValidation{
+			&FieldValidationDate{Range: &DateMinMax{Min: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)}},
+		}},
+		{ID: "bestFriend", Type: FieldTypeLink, LinkType: "Entry", Validations: []FieldValidation{
+			&FieldValidationLink{LinkContentType: []string{"cat"}},
+		}},
+		{ID: "photo", Type: FieldTypeLink, LinkType: "Asset", Validations: []FieldValidation{
+			&FieldValidationMimeType{MimeTypes: []string{MimeTypeImage}},
+			&FieldValidationDimension{Width: NewMax(1000)},
+			&FieldValidationFileSize{Size: NewMax(1024)},
+			(*FieldValidationSize)(nil),
+		}},
+	}}
+
+	validator := &EntryValidator{
+		ResolveEntry: func(id string) (*Entry, error) {
+			return &Entry{Sys: &Sys{ID: id, ContentType: &ContentType{Sys: &Sys{ID: "dog"}}}}, nil
+		},
+		ResolveAsset: func(id string) (*Asset, error) {
+			return assetWithFile("image/jpeg", 4096, 2000), nil
+		},
+	}
+
+	entry := &Entry{Fields: map[string]interface{}{
+		"name":       map[string]interface{}{"en-US": "Hot dog 2"},
+		"lives":      map[string]interface{}{"en-US": float64(10)},
+		"color":      map[string]interface{}{"en-US": "rainbow"},
+		"birthday":   map[string]interface{}{"en-US": "1999-12-31"},
+		"bestFriend": map[string]interface{}{"en-US": linkValue("Entry", "doge")},
+		"photo":      map[string]interface{}{"en-US": linkValue("Asset", "huge")},
+	}}
+
+	rules := []string{}
+	for _, err := range validator.Validate(entry, ct, []string{"en-US"}) {
+		rules = append(rules, err.FieldID+"/"+err.Rule)
+	}
+	assert.Equal([]string{
+		"name/regexp",
+		"name/prohibitRegexp",
+		"lives/range",
+		"color/in",
+		"birthday/dateRange",
+		"bestFriend/linkContentType",
+		"photo/assetImageDimensions",
+		"photo/assetFileSize",
+	}, rules)
+}
+
+func TestValidateRichTextField(t *testing.T) {
+	assert := assert.New(t)
+
+	field := richTextFieldFromTestData(t)
+	field.ID = "body"
+	ct := &ContentType{Name: "Article", Fields: []*Field{field}}
+
+	doc := NewRichTextDocument(
+		NewRichTextNode(RichTextNodeHeading1, NewRichTextText("Cats")),
+		NewRichTextEmbed(RichTextNodeEmbeddedEntryBlock, NewLink("Entry", "doge")),
+	)
+
+	validator := &EntryValidator{
+		ResolveEntry: func(id string) (*Entry, error) {
+			return &Entry{Sys: &Sys{ID: id, ContentType: &ContentType{Sys: &Sys{ID: "dog"}}}}, nil
+		},
+	}
+
+	entry := &Entry{Fields: map[string]interface{}{"body": map[string]interface{}{"en-US": doc}}}
+	errs := validator.Validate(entry, ct, []string{"en-US"})
+	assert.Equal(2, len(errs))
+
+	assert.Equal("enabledNodeTypes", errs[0].Rule)
+	assert.Equal([]interface{}{"content", 0}, errs[0].Path)
+
+	assert.Equal(EntryValidationLinkContentType, errs[1].Rule)
+	assert.Equal([]interface{}{"content", 1}, errs[1].Path)
+	assert.Equal("rich-text only embeds cats", errs[1].Message)
+}
+
+func TestValidateRichTextFieldPointerNodes(t *testing.T) {
+	assert := assert.New(t)
+
+	ct := &ContentType{Fields: []*Field{{ID: "body", Type: FieldTypeRichText, Validations: []FieldValidation{
+		&FieldValidationNodes{Nodes: map[string][]FieldValidation{
+			RichTextNodeEmbeddedEntryBlock: {&FieldValidationLink{LinkContentType: []string{"cat"}}},
+		}},
+	}}}}
+
+	validator := &EntryValidator{
+		ResolveEntry: func(id string) (*Entry, error) {
+			return &Entry{Sys: &Sys{ID: id, ContentType: &ContentType{Sys: &Sys{ID: "dog"}}}}, nil
+		},
+	}
+
+	doc := NewRichTextDocument(NewRichTextEmbed(RichTextNodeEmbeddedEntryBlock, NewLink("Entry", "doge")))
+	entry := &Entry{Fields: map[string]interface{}{"body": map[string]interface{}{"en-US": doc}}}
+
+	errs := validator.Validate(entry, ct, []string{"en-US"})
+	assert.Equal(1, len(errs))
+	assert.Equal(EntryValidationLinkContentType, errs[0].Rule)
+	assert.Equal([]interface{}{"content", 0}, errs[0].Path)
+}
+
+func TestMimeTypeGroup(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(MimeTypeImage, MimeTypeGroup("image/svg+xml"))
+	assert.Equal(MimeTypeVideo, MimeTypeGroup("video/mp4"))
+	assert.Equal(MimeTypePDF, MimeTypeGroup("application/pdf"))
+	assert.Equal(MimeTypeMarkup, MimeTypeGroup("text/html; charset=utf-8"))
+	assert.Equal(MimeTypeAttachment, MimeTypeGroup("application/octet-stream"))
+}
+
+func TestEntriesServiceValidate(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/content_types/cat"):
+			fmt.Fprintln(w, `{"sys": {"id": "cat"}, "fields": [
+				{"id": "name", "type": "Symbol", "required": true, "localized": true},
+				{"id": "bestFriend", "type": "Link", "linkType": "Entry", "validations": [{"linkContentType": ["cat"]}]}
+			]}`)
+		case strings.HasSuffix(r.URL.Path, "/locales"):
+			fmt.Fprintln(w, `{"sys": {"type": "Array"}, "total": 2, "items": [
+				{"code": "de-DE", "default": false},
+				{"code": "en-US", "default": true}
+			]}`)
+		case strings.HasSuffix(r.URL.Path, "/entries/doge"):
+			fmt.Fprintln(w, `{"sys": {"id": "doge", "contentType": {"sys": {"id": "dog"}}}, "fields": {}}`)
+		default:
+			w.WriteHeader(404)
+			fmt.Fprintln(w, string(readTestData("error-notfound.json")))
+		}
+	})
+
+	// test server
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	entry := &Entry{
+		Sys: &Sys{ContentType: &ContentType{Sys: &Sys{ID: "cat"}}},
+		Fields: map[string]interface{}{
+			"name":       map[string]interface{}{"en-US": "Nyan Cat"},
+			"bestFriend": map[string]interface{}{"en-US": linkValue("Entry", "doge")},
+		},
+	}
+
+	errs, err := cma.Entries.Validate(spaceID, entry)
+	assert.Nil(err)
+	assert.Equal(2, len(errs))
+	assert.Equal(EntryValidationRequired, errs[0].Rule)
+	assert.Equal("de-DE", errs[0].Locale)
+	assert.Equal(EntryValidationLinkContentType, errs[1].Rule)
+	assert.Equal("en-US", errs[1].Locale)
+
+	errs, err = cma.Entries.Validate(spaceID, entry, "en-US")
+	assert.Nil(err)
+	assert.Equal(1, len(errs))
+
+	entry.Fields["bestFriend"] = map[string]interface{}{"en-US": linkValue("Entry", "grumpycat")}
+	errs, err = cma.Entries.Validate(spaceID, entry, "en-US")
+	assert.Nil(err)
+	assert.Equal(EntryValidationNotResolvable, errs[0].Rule)
+
+	_, err = cma.Entries.Validate(spaceID, &Entry{})
+	assert.NotNil(err)
+}
@@ -1,11 +1,54 @@
 package contentful
 
 import (
-	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 )
 
+// Sentinels of the api errors, to be matched with errors.Is
+var (
+	ErrBadRequest         = errors.New("contentful: bad request")
+	ErrInvalidQuery       = errors.New("contentful: invalid query")
+	ErrAccessTokenInvalid = errors.New("contentful: access token invalid")
+	ErrAccessDenied       = errors.New("contentful: access denied")
+	ErrNotFound           = errors.New("contentful: not found")
+	ErrVersionMismatch    = errors.New("contentful: version mismatch")
+	ErrValidationFailed   = errors.New("contentful: validation failed")
+	ErrRateLimitExceeded  = errors.New("contentful: rate limit exceeded")
+	ErrServerError        = errors.New("contentful: server error")
+)
+
+// errorIDs maps the sys.id of api errors to their sentinel
+var errorIDs = map[string]error{
+	"BadRequest":         ErrBadRequest,
+	"InvalidQuery":       ErrInvalidQuery,
+	"UnknownField":       ErrInvalidQuery,
+	"AccessTokenInvalid": ErrAccessTokenInvalid,
+	"AccessDenied":       ErrAccessDenied,
+	"NotFound":           ErrNotFound,
+	"VersionMismatch":    ErrVersionMismatch,
+	"Conflict":           ErrVersionMismatch,
+	"ValidationFailed":   ErrValidationFailed,
+	"InvalidEntry":       ErrValidationFailed,
+	"RateLimitExceeded":  ErrRateLimitExceeded,
+	"ServerError":        ErrServerError,
+}
+
+// errorStatuses maps http status codes to the sentinel of errors without a known sys.id,
+// like the html pages of gateways
+var errorStatuses = map[int]error{
+	http.StatusBadRequest:          ErrBadRequest,
+	http.StatusUnauthorized:        ErrAccessTokenInvalid,
+	http.StatusForbidden:           ErrAccessDenied,
+	http.StatusNotFound:            ErrNotFound,
+	http.StatusConflict:            ErrVersionMismatch,
+	http.StatusUnprocessableEntity: ErrValidationFailed,
+	http.StatusTooManyRequests:     ErrRateLimitExceeded,
+}
+
 // ErrorResponse model
 type ErrorResponse struct {
 	Sys       *Sys          `json:"sys"`
@@ -30,13 +73,147 @@ type ErrorDetail struct {
 	Path    interface{} `json:"path,omitempty"`
 	Details string      `json:"details,omitempty"`
 	Value   interface{} `json:"value,omitempty"`
+
+	// Constraints holds the other properties of the detail, the expectations
+	// of the failed validation such as min and max or contentTypeId
+	Constraints map[string]interface{} `json:"-"`
+}
+
+// UnmarshalJSON for custom json unmarshaling
+func (detail *ErrorDetail) UnmarshalJSON(data []byte) error {
+	type Alias ErrorDetail
+	if err := json.Unmarshal(data, (*Alias)(detail)); err != nil {
+		return err
+	}
+
+	payload := map[string]interface{}{}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	for _, key := range []string{"id", "name", "path", "details", "value"} {
+		delete(payload, key)
+	}
+
+	if len(payload) > 0 {
+		detail.Constraints = payload
+	}
+
+	return nil
 }
 
-// APIError model
+// APIError model holds the request and response of a failed api call.
+// It is embedded by the typed errors, which are returned instead whenever the kind of the error is known.
 type APIError struct {
-	req *http.Request
-	res *http.Response
-	err *ErrorResponse
+	req  *http.Request
+	res  *http.Response
+	err  *ErrorResponse
+	body []byte
+	kind error
+}
+
+// newAPIError returns the typed error of the kind of the api error
+func newAPIError(req *http.Request, res *http.Response, body []byte, e *ErrorResponse) error {
+	apiError := APIError{req: req, res: res, err: e, body: body}
+
+	if e.Sys != nil {
+		apiError.kind = errorIDs[e.Sys.ID]
+	}
+
+	if apiError.kind == nil && res != nil {
+		apiError.kind = errorStatuses[res.StatusCode]
+		if res.StatusCode >= http.StatusInternalServerError {
+			apiError.kind = ErrServerError
+		}
+	}
+
+	switch apiError.kind {
+	case ErrBadRequest:
+		return BadRequestError{apiError}
+	case ErrInvalidQuery:
+		return InvalidQueryError{apiError}
+	case ErrAccessTokenInvalid:
+		return AccessTokenInvalidError{apiError}
+	case ErrAccessDenied:
+		return AccessDeniedError{apiError}
+	case ErrNotFound:
+		return NotFoundError{apiError}
+	case ErrVersionMismatch:
+		return VersionMismatchError{apiError}
+	case ErrValidationFailed:
+		return ValidationFailedError{apiError}
+	case ErrRateLimitExceeded:
+		return RateLimitExceededError{apiError}
+	case ErrServerError:
+		return ServerError{apiError}
+	}
+
+	return apiError
+}
+
+func (e APIError) Error() string {
+	if e.err != nil && e.err.Message != "" {
+		return e.err.Message
+	}
+
+	status := e.StatusCode()
+	return fmt.Sprintf("contentful: %d %s", status, http.StatusText(status))
+}
+
+// Is reports whether the error is of the kind of the given sentinel, e.g. ErrNotFound
+func (e APIError) Is(target error) bool {
+	return e.kind != nil && e.kind == target
+}
+
+// Unwrap returns the error response of the api
+func (e APIError) Unwrap() error {
+	if e.err == nil {
+		return nil
+	}
+
+	return *e.err
+}
+
+// StatusCode returns the http status code of the response
+func (e APIError) StatusCode() int {
+	if e.res == nil {
+		return 0
+	}
+
+	return e.res.StatusCode
+}
+
+// RequestID returns the id of the failed request, to be given to the contentful support
+func (e APIError) RequestID() string {
+	if e.err != nil && e.err.RequestID != "" {
+		return e.err.RequestID
+	}
+
+	return e.Header().Get("X-Contentful-Request-Id")
+}
+
+// Header returns the headers of the response
+func (e APIError) Header() http.Header {
+	if e.res == nil {
+		return http.Header{}
+	}
+
+	return e.res.Header
+}
+
+// Body returns the raw body of the response
+func (e APIError) Body() []byte {
+	return e.body
+}
+
+// Response returns the decoded error response, the message is the http status for bodies which are not json
+func (e APIError) Response() *ErrorResponse {
+	return e.err
+}
+
+// Request returns the failed request
+func (e APIError) Request() *http.Request {
+	return e.req
 }
 
 // AccessTokenInvalidError for 401 errors
@@ -45,7 +222,7 @@ type AccessTokenInvalidError struct {
 }
 
 func (e AccessTokenInvalidError) Error() string {
-	return e.APIError.err.Message
+	return e.APIError.Error()
 }
 
 // VersionMismatchError for 409 errors
@@ -54,6 +231,10 @@ type VersionMismatchError struct {
 }
 
 func (e VersionMismatchError) Error() string {
+	if e.APIError.req == nil {
+		return e.APIError.Error()
+	}
+
 	return "Version " + e.APIError.req.Header.Get("X-Contentful-Version") + " is mismatched"
 }
 
@@ -63,16 +244,105 @@ type ValidationFailedError struct {
 }
 
 func (e ValidationFailedError) Error() string {
-	msg := bytes.Buffer{}
+	lines := []string{}
+	for _, violation := range e.Violations() {
+		lines = append(lines, violation.String())
+	}
+
+	if len(lines) == 0 && e.APIError.err != nil {
+		return e.APIError.err.Message
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// ValidationViolation describes one failed validation of a ValidationFailedError
+type ValidationViolation struct {
+	// Name of the failed validation, e.g. required, size or linkContentType
+	Name    string
+	Details string
+
+	// Path as returned by the api, e.g. ["fields", "title", "en-US"]
+	Path []interface{}
+
+	// FieldID, Locale and Index are parsed from paths into entry and asset fields.
+	// Index is the position of the failing array item, -1 for the value as a whole.
+	FieldID string
+	Locale  string
+	Index   int
+
+	// Constraints are the expectations of the validation, such as min and max of size
+	Constraints map[string]interface{}
+
+	// Value is the offending value, when the api returns it
+	Value interface{}
+}
+
+func (v ValidationViolation) String() string {
+	path := []string{}
+	for _, segment := range v.Path {
+		path = append(path, fmt.Sprint(segment))
+	}
+
+	details := v.Details
+	if details == "" {
+		details = "validation failed"
+	}
 
-	for _, err := range e.APIError.err.Details.Errors {
-		if err.Name == "uniqueFieldIds" || err.Name == "uniqueFieldApiNames" {
-			return msg.String()
+	if len(path) == 0 {
+		return fmt.Sprintf("%s: %s", v.Name, details)
+	}
+
+	return fmt.Sprintf("%s at %s: %s", v.Name, strings.Join(path, "."), details)
+}
+
+// Violations returns the failed validations with their paths parsed
+func (e ValidationFailedError) Violations() []ValidationViolation {
+	violations := []ValidationViolation{}
+	if e.APIError.err == nil || e.APIError.err.Details == nil {
+		return violations
+	}
+
+	for _, detail := range e.APIError.err.Details.Errors {
+		violation := ValidationViolation{
+			Name:        detail.Name,
+			Details:     detail.Details,
+			Path:        []interface{}{},
+			Index:       -1,
+			Constraints: detail.Constraints,
+			Value:       detail.Value,
+		}
+
+		switch path := detail.Path.(type) {
+		case []interface{}:
+			violation.Path = path
+		case string:
+			violation.Path = []interface{}{path}
+		}
+
+		// entry and asset paths are fields, field id, locale and optionally an array index
+		if len(violation.Path) > 1 && violation.Path[0] == "fields" {
+			violation.FieldID, _ = violation.Path[1].(string)
+
+			if len(violation.Path) > 2 {
+				violation.Locale, _ = violation.Path[2].(string)
+			}
+
+			if len(violation.Path) > 3 {
+				if index, ok := violation.Path[3].(float64); ok {
+					violation.Index = int(index)
+				}
+			}
 		}
-		msg.WriteString(fmt.Sprintf("%s\n", err.Details))
+
+		if violation.Constraints == nil {
+			violation.Constraints = map[string]interface{}{}
+		}
+
+		violations = append(violations, violation)
 	}
 
-	return msg.String()
+	return violations
 }
 
 // NotFoundError for 404 errors
@@ -90,17 +360,25 @@ type RateLimitExceededError struct {
 }
 
 func (e RateLimitExceededError) Error() string {
-	return e.APIError.err.Message
+	return e.APIError.Error()
 }
 
 // BadRequestError error model for bad request responses
-type BadRequestError struct{}
+type BadRequestError struct {
+	APIError
+}
 
 // InvalidQueryError error model for invalid query responses
-type InvalidQueryError struct{}
+type InvalidQueryError struct {
+	APIError
+}
 
 // AccessDeniedError error model for access denied responses
-type AccessDeniedError struct{}
+type AccessDeniedError struct {
+	APIError
+}
 
 // ServerError error model for server error responses
-type ServerError struct{}
+type ServerError struct {
+	APIError
+}
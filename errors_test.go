@@ -1,6 +1,7 @@
 package contentful
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -69,3 +70,184 @@ func TestRateLimitExceededResponse(t *testing.T) {
 	assert.Equal("Error", rateLimitExceededError.APIError.err.Sys.Type)
 	assert.Equal("RateLimitExceeded", rateLimitExceededError.APIError.err.Sys.ID)
 }
+
+func TestValidationFailedErrorResponse(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(422)
+		fmt.Fprintln(w, string(readTestData("error-validationfailed.json")))
+	})
+
+	// test server
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	err = cma.Entries.Upsert(spaceID, &Entry{Sys: &Sys{ID: "nyancat", ContentType: &ContentType{Sys: &Sys{ID: "cat"}}}})
+	assert.NotNil(err)
+	validationFailedError, ok := err.(ValidationFailedError)
+	assert.True(ok)
+
+	violations := validationFailedError.Violations()
+	assert.Equal(5, len(violations))
+
+	assert.Equal("required", violations[0].Name)
+	assert.Equal("name", violations[0].FieldID)
+	assert.Equal("", violations[0].Locale)
+	assert.Equal(-1, violations[0].Index)
+
+	assert.Equal("size", violations[1].Name)
+	assert.Equal("en-US", violations[1].Locale)
+	assert.Equal(float64(10), violations[1].Constraints["max"])
+	assert.Equal("Nyan Cat the Great", violations[1].Value)
+
+	assert.Equal("friends", violations[2].FieldID)
+	assert.Equal(1, violations[2].Index)
+	assert.Equal([]interface{}{"cat"}, violations[2].Constraints["contentTypeId"])
+
+	assert.Equal([]interface{}{"fields"}, violations[3].Path)
+	assert.Equal("", violations[3].FieldID)
+	assert.Equal(map[string]interface{}{}, violations[3].Constraints)
+
+	assert.Equal([]interface{}{"schwarz", "weiss"}, violations[4].Constraints["expected"])
+	assert.Equal("de-DE", violations[4].Locale)
+
+	// every violation is listed, including the ones after unique field id errors
+	assert.Equal(
+		"required at fields.name: The property \"name\" is required here\n"+
+			"size at fields.name.en-US: Size must be at most 10\n"+
+			"linkContentType at fields.friends.en-US.1: Link to entry doge has invalid content type\n"+
+			"uniqueFieldIds at fields: Field ids must be unique\n"+
+			"in at fields.color.de-DE: Value must be one of expected values",
+		err.Error(),
+	)
+}
+
+func TestValidationFailedErrorWithoutDetails(t *testing.T) {
+	assert := assert.New(t)
+
+	err := ValidationFailedError{APIError{err: &ErrorResponse{Message: "Validation error"}}}
+	assert.Equal([]ValidationViolation{}, err.Violations())
+	assert.Equal("Validation error", err.Error())
+}
+
+func TestErrorsIsAndAs(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Contentful-Request-Id", "header-request-id")
+		w.WriteHeader(404)
+		fmt.Fprintln(w, string(readTestData("error-notfound.json")))
+	})
+
+	// test server
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	_, err = cma.Spaces.Get("unknown-space-id")
+	wrapped := fmt.Errorf("reading space: %w", err)
+	assert.True(errors.Is(wrapped, ErrNotFound))
+	assert.False(errors.Is(wrapped, ErrServerError))
+
+	var notFoundError NotFoundError
+	assert.True(errors.As(wrapped, &notFoundError))
+	assert.Equal(404, notFoundError.StatusCode())
+	assert.Equal("request-id", notFoundError.RequestID())
+	assert.Equal("header-request-id", notFoundError.Header().Get("X-Contentful-Request-Id"))
+	assert.Contains(string(notFoundError.Body()), "The resource could not be found.")
+	assert.Equal("NotFound", notFoundError.Response().Sys.ID)
+
+	var errorResponse ErrorResponse
+	assert.True(errors.As(wrapped, &errorResponse))
+	assert.Equal("The resource could not be found.", errorResponse.Message)
+}
+
+func TestNonJSONErrorResponse(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Header().Set("X-Contentful-Request-Id", "gateway-request-id")
+		w.WriteHeader(502)
+		fmt.Fprintln(w, "<html><body><h1>502 Bad Gateway</h1></body></html>")
+	})
+
+	// test server
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	_, err = cma.Spaces.Get("space-id")
+	assert.True(errors.Is(err, ErrServerError))
+
+	serverError, ok := err.(ServerError)
+	assert.True(ok)
+	assert.Equal(502, serverError.StatusCode())
+	assert.Equal("gateway-request-id", serverError.RequestID())
+	assert.Equal("502 Bad Gateway", serverError.Error())
+	assert.Contains(string(serverError.Body()), "<h1>502 Bad Gateway</h1>")
+}
+
+func TestUnknownErrorFallsBackToStatus(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(403)
+		fmt.Fprintln(w, `{"sys":{"type":"Error","id":"SomethingNew"},"message":"Something new happened"}`)
+	})
+
+	// test server
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	_, err = cma.Spaces.Get("space-id")
+	assert.True(errors.Is(err, ErrAccessDenied))
+	accessDeniedError, ok := err.(AccessDeniedError)
+	assert.True(ok)
+	assert.Equal("Something new happened", accessDeniedError.Error())
+}
+
+func TestErrorIDs(t *testing.T) {
+	assert := assert.New(t)
+
+	for id, kind := range map[string]error{
+		"BadRequest":   ErrBadRequest,
+		"InvalidQuery": ErrInvalidQuery,
+		"UnknownField": ErrInvalidQuery,
+		"AccessDenied": ErrAccessDenied,
+		"Conflict":     ErrVersionMismatch,
+		"InvalidEntry": ErrValidationFailed,
+		"ServerError":  ErrServerError,
+	} {
+		res := &http.Response{StatusCode: 418, Header: http.Header{}}
+		err := newAPIError(nil, res, nil, &ErrorResponse{Sys: &Sys{ID: id}, Message: id})
+		assert.True(errors.Is(err, kind), id)
+		assert.Equal(id, err.Error())
+	}
+
+	// unknown ids with unmapped statuses stay plain api errors
+	res := &http.Response{StatusCode: 418, Header: http.Header{}}
+	err := newAPIError(nil, res, nil, &ErrorResponse{Sys: &Sys{ID: "Teapot"}})
+	_, ok := err.(APIError)
+	assert.True(ok)
+	assert.Equal("contentful: 418 I'm a teapot", err.Error())
+}
@@ -1,6 +1,6 @@
 module github.com/contentful-labs/contentful-go
 
-go 1.12
+go 1.13
 
 require (
 	github.com/davecgh/go-spew v1.1.0
@@ -0,0 +1,324 @@
+package contentful
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// imageMaxSize is the largest width or height served by the Images API
+	imageMaxSize = 4000
+
+	// ImageRadiusMax crops the image to a circle or an ellipse
+	ImageRadiusMax = -1
+)
+
+// ImageFit defines how an image is resized to the requested dimensions
+type ImageFit string
+
+// Images API fit modes
+const (
+	ImageFitPad   ImageFit = "pad"
+	ImageFitFill  ImageFit = "fill"
+	ImageFitScale ImageFit = "scale"
+	ImageFitCrop  ImageFit = "crop"
+	ImageFitThumb ImageFit = "thumb"
+)
+
+// ImageFocus defines the area kept when an image is cropped
+type ImageFocus string
+
+// Images API focus areas
+const (
+	ImageFocusCenter      ImageFocus = "center"
+	ImageFocusTop         ImageFocus = "top"
+	ImageFocusRight       ImageFocus = "right"
+	ImageFocusLeft        ImageFocus = "left"
+	ImageFocusBottom      ImageFocus = "bottom"
+	ImageFocusTopRight    ImageFocus = "top_right"
+	ImageFocusTopLeft     ImageFocus = "top_left"
+	ImageFocusBottomRight ImageFocus = "bottom_right"
+	ImageFocusBottomLeft  ImageFocus = "bottom_left"
+	ImageFocusFace        ImageFocus = "face"
+	ImageFocusFaces       ImageFocus = "faces"
+)
+
+// ImageFormat defines the format an image is converted to
+type ImageFormat string
+
+// Images API formats
+const (
+	ImageFormatJPG            ImageFormat = "jpg"
+	ImageFormatProgressiveJPG ImageFormat = "progressive-jpg"
+	ImageFormatPNG            ImageFormat = "png"
+	ImageFormatPNG8           ImageFormat = "png8"
+	ImageFormatWebP           ImageFormat = "webp"
+	ImageFormatAVIF           ImageFormat = "avif"
+	ImageFormatGIF            ImageFormat = "gif"
+)
+
+var imageBackgroundRegex = regexp.MustCompile(`^rgb:[0-9a-fA-F]{6}$`)
+
+// ImageURL builds Images API urls for an image file.
+// Setters can be chained, parameters are validated when the url is built.
+type ImageURL struct {
+	base       string
+	err        error
+	width      int
+	height     int
+	fit        ImageFit
+	focus      ImageFocus
+	radius     int
+	background string
+	quality    int
+	format     ImageFormat
+}
+
+// Image returns an Images API url builder for the file
+func (file *File) Image() *ImageURL {
+	if file == nil || file.URL == "" {
+		return &ImageURL{err: fmt.Errorf("file has no url, it has to be processed first")}
+	}
+
+	return &ImageURL{base: file.URL}
+}
+
+// Image returns an Images API url builder for the file of the given locale
+func (asset *Asset) Image(locale string) *ImageURL {
+	file := asset.File(locale)
+	if file == nil {
+		return &ImageURL{err: fmt.Errorf("asset has no file for locale %q", locale)}
+	}
+
+	return file.Image()
+}
+
+func (image *ImageURL) clone() *ImageURL {
+	c := *image
+	return &c
+}
+
+// Width sets the width in pixels
+func (image *ImageURL) Width(width int) *ImageURL {
+	image.width = width
+	return image
+}
+
+// Height sets the height in pixels
+func (image *ImageURL) Height(height int) *ImageURL {
+	image.height = height
+	return image
+}
+
+// Fit sets the resizing behavior
+func (image *ImageURL) Fit(fit ImageFit) *ImageURL {
+	image.fit = fit
+	return image
+}
+
+// Focus sets the focus area, only allowed with the thumb, fill and crop fit modes.
+// Face detection with ImageFocusFace and ImageFocusFaces only works with the thumb fit.
+func (image *ImageURL) Focus(focus ImageFocus) *ImageURL {
+	image.focus = focus
+	return image
+}
+
+// Radius sets the corner radius in pixels, ImageRadiusMax for a circle or an ellipse
+func (image *ImageURL) Radius(radius int) *ImageURL {
+	image.radius = radius
+	return image
+}
+
+// Background sets the background color used with the pad fit mode and rounded corners,
+// given either as `rgb:RRGGBB` or `#RRGGBB`
+func (image *ImageURL) Background(color string) *ImageURL {
+	if strings.HasPrefix(color, "#") {
+		color = "rgb:" + color[1:]
+	}
+
+	image.background = color
+	return image
+}
+
+// Quality sets the compression quality between 1 and 100
+func (image *ImageURL) Quality(quality int) *ImageURL {
+	image.quality = quality
+	return image
+}
+
+// Format sets the format the image is converted to
+func (image *ImageURL) Format(format ImageFormat) *ImageURL {
+	image.format = format
+	return image
+}
+
+func (image *ImageURL) validate() error {
+	if image.err != nil {
+		return image.err
+	}
+
+	if image.width < 0 || image.width > imageMaxSize {
+		return fmt.Errorf("image width must be at most %d, or 0 for the original width, got %d", imageMaxSize, image.width)
+	}
+
+	if image.height < 0 || image.height > imageMaxSize {
+		return fmt.Errorf("image height must be at most %d, or 0 for the original height, got %d", imageMaxSize, image.height)
+	}
+
+	switch image.fit {
+	case "", ImageFitPad, ImageFitFill, ImageFitScale, ImageFitCrop, ImageFitThumb:
+	default:
+		return fmt.Errorf("unknown image fit %q", image.fit)
+	}
+
+	switch image.focus {
+	case "":
+	case ImageFocusCenter, ImageFocusTop, ImageFocusRight, ImageFocusLeft, ImageFocusBottom,
+		ImageFocusTopRight, ImageFocusTopLeft, ImageFocusBottomRight, ImageFocusBottomLeft:
+		if image.fit != ImageFitThumb && image.fit != ImageFitFill && image.fit != ImageFitCrop {
+			return fmt.Errorf("image focus requires the thumb, fill or crop fit, got %q", image.fit)
+		}
+	case ImageFocusFace, ImageFocusFaces:
+		if image.fit != ImageFitThumb {
+			return fmt.Errorf("image focus %q requires the thumb fit, got %q", image.focus, image.fit)
+		}
+	default:
+		return fmt.Errorf("unknown image focus %q", image.focus)
+	}
+
+	if image.radius < ImageRadiusMax {
+		return fmt.Errorf("image radius must be at least 0, or ImageRadiusMax, got %d", image.radius)
+	}
+
+	if image.background != "" {
+		if !imageBackgroundRegex.MatchString(image.background) {
+			return fmt.Errorf("image background must be formatted as rgb:RRGGBB, got %q", image.background)
+		}
+
+		if image.fit != ImageFitPad && image.radius == 0 {
+			return fmt.Errorf("image background requires the pad fit or a radius, got fit %q", image.fit)
+		}
+	}
+
+	switch image.format {
+	case "", ImageFormatJPG, ImageFormatProgressiveJPG, ImageFormatPNG, ImageFormatPNG8,
+		ImageFormatWebP, ImageFormatAVIF, ImageFormatGIF:
+	default:
+		return fmt.Errorf("unknown image format %q", image.format)
+	}
+
+	if image.quality != 0 {
+		if image.quality < 1 || image.quality > 100 {
+			return fmt.Errorf("image quality must be between 1 and 100, got %d", image.quality)
+		}
+
+		switch image.format {
+		case ImageFormatPNG, ImageFormatPNG8, ImageFormatGIF:
+			return fmt.Errorf("image quality can not be set for the %s format", image.format)
+		}
+	}
+
+	return nil
+}
+
+// Values returns the Images API query parameters
+func (image *ImageURL) Values() (url.Values, error) {
+	if err := image.validate(); err != nil {
+		return nil, err
+	}
+
+	values := url.Values{}
+
+	if image.width != 0 {
+		values.Set("w", strconv.Itoa(image.width))
+	}
+
+	if image.height != 0 {
+		values.Set("h", strconv.Itoa(image.height))
+	}
+
+	if image.fit != "" {
+		values.Set("fit", string(image.fit))
+	}
+
+	if image.focus != "" {
+		values.Set("f", string(image.focus))
+	}
+
+	if image.radius == ImageRadiusMax {
+		values.Set("r", "max")
+	} else if image.radius != 0 {
+		values.Set("r", strconv.Itoa(image.radius))
+	}
+
+	if image.background != "" {
+		values.Set("bg", image.background)
+	}
+
+	if image.quality != 0 {
+		values.Set("q", strconv.Itoa(image.quality))
+	}
+
+	switch image.format {
+	case "":
+	case ImageFormatProgressiveJPG:
+		values.Set("fm", string(ImageFormatJPG))
+		values.Set("fl", "progressive")
+	case ImageFormatPNG8:
+		values.Set("fm", string(ImageFormatPNG))
+		values.Set("fl", "png8")
+	default:
+		values.Set("fm", string(image.format))
+	}
+
+	return values, nil
+}
+
+// Build returns the image url
+func (image *ImageURL) Build() (string, error) {
+	values, err := image.Values()
+	if err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(image.base)
+	if err != nil {
+		return "", err
+	}
+
+	u.RawQuery = values.Encode()
+
+	return u.String(), nil
+}
+
+// SrcSet returns a `srcset` attribute value with one url per given width.
+// When a height is set, it is scaled to keep the aspect ratio of every candidate.
+func (image *ImageURL) SrcSet(widths ...int) (string, error) {
+	if len(widths) == 0 {
+		return "", fmt.Errorf("srcset requires at least one width")
+	}
+
+	candidates := []string{}
+	for _, width := range widths {
+		if width <= 0 {
+			return "", fmt.Errorf("srcset widths must be positive, got %d", width)
+		}
+
+		candidate := image.clone().Width(width)
+		if image.height != 0 && image.width != 0 {
+			candidate.Height(image.height * width / image.width)
+		}
+
+		u, err := candidate.Build()
+		if err != nil {
+			return "", err
+		}
+
+		candidates = append(candidates, fmt.Sprintf("%s %dw", u, width))
+	}
+
+	return strings.Join(candidates, ", "), nil
+}
@@ -0,0 +1,115 @@
+package contentful
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const imageTestURL = "//images.ctfassets.net/id1/nyancat/nyancat.png"
+
+func TestImageURLBuild(t *testing.T) {
+	assert := assert.New(t)
+
+	file := &File{URL: imageTestURL}
+
+	u, err := file.Image().Build()
+	assert.Nil(err)
+	assert.Equal(imageTestURL, u)
+
+	u, err = file.Image().
+		Width(400).
+		Height(300).
+		Fit(ImageFitThumb).
+		Focus(ImageFocusFaces).
+		Radius(ImageRadiusMax).
+		Quality(80).
+		Format(ImageFormatProgressiveJPG).
+		Build()
+	assert.Nil(err)
+	assert.Equal(imageTestURL+"?f=faces&fit=thumb&fl=progressive&fm=jpg&h=300&q=80&r=max&w=400", u)
+
+	u, err = file.Image().Fit(ImageFitPad).Background("#FF00aa").Format(ImageFormatPNG8).Build()
+	assert.Nil(err)
+	assert.Equal(imageTestURL+"?bg=rgb%3AFF00aa&fit=pad&fl=png8&fm=png", u)
+
+	u, err = file.Image().Width(100).Format(ImageFormatAVIF).Radius(20).Build()
+	assert.Nil(err)
+	assert.Equal(imageTestURL+"?fm=avif&r=20&w=100", u)
+
+	// the background fills the rounded corners
+	u, err = file.Image().Radius(20).Background("rgb:ffffff").Build()
+	assert.Nil(err)
+	assert.Equal(imageTestURL+"?bg=rgb%3Affffff&r=20", u)
+
+	u, err = file.Image().Radius(ImageRadiusMax).Background("#000000").Build()
+	assert.Nil(err)
+	assert.Equal(imageTestURL+"?bg=rgb%3A000000&r=max", u)
+}
+
+func TestImageURLValidation(t *testing.T) {
+	assert := assert.New(t)
+
+	file := &File{URL: imageTestURL}
+
+	invalid := []*ImageURL{
+		file.Image().Width(4001),
+		file.Image().Height(-1),
+		file.Image().Fit("stretch"),
+		file.Image().Focus(ImageFocusFace),
+		file.Image().Fit(ImageFitScale).Focus(ImageFocusTop),
+		file.Image().Fit(ImageFitThumb).Focus("middle"),
+		file.Image().Fit(ImageFitFill).Focus(ImageFocusFace),
+		file.Image().Fit(ImageFitCrop).Focus(ImageFocusFaces),
+		file.Image().Radius(-2),
+		file.Image().Background("rgb:FFFFFF"),
+		file.Image().Fit(ImageFitPad).Background("white"),
+		file.Image().Quality(101),
+		file.Image().Format(ImageFormatPNG).Quality(50),
+		file.Image().Format("bmp"),
+		(&File{}).Image(),
+		(&Asset{}).Image("en-US"),
+	}
+
+	for _, image := range invalid {
+		_, err := image.Build()
+		assert.NotNil(err)
+	}
+
+	_, err := file.Image().Width(4001).Build()
+	assert.EqualError(err, "image width must be at most 4000, or 0 for the original width, got 4001")
+
+	_, err = file.Image().Fit(ImageFitFill).Focus(ImageFocusFace).Build()
+	assert.EqualError(err, `image focus "face" requires the thumb fit, got "fill"`)
+
+	_, err = file.Image().Fit(ImageFitCrop).Focus(ImageFocusTop).Build()
+	assert.Nil(err)
+}
+
+func TestImageURLSrcSet(t *testing.T) {
+	assert := assert.New(t)
+
+	asset := &Asset{}
+	asset.SetFile("en-US", &File{URL: imageTestURL})
+
+	image := asset.Image("en-US").Width(800).Height(400).Fit(ImageFitFill).Format(ImageFormatWebP)
+
+	srcset, err := image.SrcSet(320, 640)
+	assert.Nil(err)
+	assert.Equal(
+		imageTestURL+"?fit=fill&fm=webp&h=160&w=320 320w, "+
+			imageTestURL+"?fit=fill&fm=webp&h=320&w=640 640w",
+		srcset,
+	)
+
+	// the builder itself is left untouched
+	u, err := image.Build()
+	assert.Nil(err)
+	assert.Equal(imageTestURL+"?fit=fill&fm=webp&h=400&w=800", u)
+
+	_, err = image.SrcSet()
+	assert.NotNil(err)
+
+	_, err = image.SrcSet(320, 5000)
+	assert.NotNil(err)
+}
@@ -1,13 +1,67 @@
 package contentful
 
 import (
+	"fmt"
 	"net/url"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 )
 
+// queryTimeLayout is the format of time.Time values in queries, which are read as UTC
+const queryTimeLayout = "2006-01-02 15:04:05"
+
+// QueryField is the path of a field in a query, e.g. fields.title or sys.id.
+// Generated content type packages declare one constant per field, so typos fail at compile time.
+type QueryField string
+
+// FieldPath returns the query path of an entry field
+func FieldPath(id string) QueryField {
+	return QueryField("fields." + id)
+}
+
+// Sys and metadata fields available in every query
+const (
+	QueryFieldSysID               QueryField = "sys.id"
+	QueryFieldSysContentType      QueryField = "sys.contentType.sys.id"
+	QueryFieldSysCreatedAt        QueryField = "sys.createdAt"
+	QueryFieldSysUpdatedAt        QueryField = "sys.updatedAt"
+	QueryFieldSysPublishedAt      QueryField = "sys.publishedAt"
+	QueryFieldSysFirstPublishedAt QueryField = "sys.firstPublishedAt"
+	QueryFieldSysArchivedAt       QueryField = "sys.archivedAt"
+	QueryFieldSysVersion          QueryField = "sys.version"
+	QueryFieldSysPublishedVersion QueryField = "sys.publishedVersion"
+	QueryFieldTags                QueryField = "metadata.tags.sys.id"
+)
+
+// LocaleAll requests the values of every locale
+const LocaleAll = "*"
+
+func (field QueryField) String() string {
+	return string(field)
+}
+
+// Field returns the path of a field of the entry linked by the field, for reference search,
+// e.g. FieldPath("author").Field("name") is fields.author.fields.name
+func (field QueryField) Field(id string) QueryField {
+	return QueryField(string(field) + ".fields." + id)
+}
+
+// Sys returns the path of a sys property of the entry linked by the field,
+// e.g. FieldPath("author").Sys("contentType.sys.id")
+func (field QueryField) Sys(path string) QueryField {
+	return QueryField(string(field) + ".sys." + path)
+}
+
+// isReferenceSearch reports whether the field reaches into linked entries, which the api only
+// accepts together with a content type
+func isReferenceSearch(field string) bool {
+	return strings.HasPrefix(field, "fields.") &&
+		(strings.Contains(field[len("fields."):], ".fields.") || strings.Contains(field[len("fields."):], ".sys."))
+}
+
 //Query model
 type Query struct {
 	include     uint16
@@ -15,9 +69,9 @@ type Query struct {
 	fields      []string
 	e           map[string]interface{}
 	ne          map[string]interface{}
-	all         map[string][]string
-	in          map[string][]string
-	nin         map[string][]string
+	all         map[string][]interface{}
+	in          map[string][]interface{}
+	nin         map[string][]interface{}
 	exists      []string
 	notExists   []string
 	lt          map[string]interface{}
@@ -26,13 +80,20 @@ type Query struct {
 	gte         map[string]interface{}
 	q           string
 	match       map[string]string
-	near        map[string]string
-	within      map[string]string
+	near        map[string][]float64
+	within      map[string][]float64
+	linksEntry  string
+	linksAsset  string
 	order       []string
 	limit       uint16
 	skip        uint16
 	mime        string
 	locale      string
+
+	// set holds the parameters given explicitly, which are built even for zero values
+	set map[string]bool
+	// geo holds the geo parameters read by ParseQuery, which are built as they were read
+	geo map[string]string
 }
 
 //NewQuery initilazies a new query
@@ -43,9 +104,9 @@ func NewQuery() *Query {
 		fields:      []string{},
 		e:           make(map[string]interface{}),
 		ne:          make(map[string]interface{}),
-		all:         make(map[string][]string),
-		in:          make(map[string][]string),
-		nin:         make(map[string][]string),
+		all:         make(map[string][]interface{}),
+		in:          make(map[string][]interface{}),
+		nin:         make(map[string][]interface{}),
 		exists:      []string{},
 		notExists:   []string{},
 		lt:          make(map[string]interface{}),
@@ -54,25 +115,31 @@ func NewQuery() *Query {
 		gte:         make(map[string]interface{}),
 		q:           "",
 		match:       make(map[string]string),
-		near:        make(map[string]string),
-		within:      make(map[string]string),
+		near:        make(map[string][]float64),
+		within:      make(map[string][]float64),
+		linksEntry:  "",
+		linksAsset:  "",
 		order:       []string{},
 		limit:       0,
 		skip:        0,
 		mime:        "",
 		locale:      "",
+		set:         make(map[string]bool),
+		geo:         make(map[string]string),
 	}
 }
 
 //Include query
 func (q *Query) Include(include uint16) *Query {
 	q.include = include
+	q.set["include"] = true
 	return q
 }
 
 //ContentType query
 func (q *Query) ContentType(ct string) *Query {
 	q.contentType = ct
+	q.set["content_type"] = true
 	return q
 }
 
@@ -96,22 +163,50 @@ func (q *Query) NotEqual(field string, value interface{}) *Query {
 
 //All [all] query
 func (q *Query) All(field string, value []string) *Query {
-	q.all[field] = value
+	q.all[field] = stringValues(value)
 	return q
 }
 
 //In [in] query
 func (q *Query) In(field string, value []string) *Query {
-	q.in[field] = value
+	q.in[field] = stringValues(value)
 	return q
 }
 
 //NotIn [nin] query
 func (q *Query) NotIn(field string, value []string) *Query {
-	q.nin[field] = value
+	q.nin[field] = stringValues(value)
 	return q
 }
 
+func stringValues(values []string) []interface{} {
+	list := make([]interface{}, len(values))
+	for i, value := range values {
+		list[i] = value
+	}
+
+	return list
+}
+
+//TagsIn matches entities linked to any of the given tags
+func (q *Query) TagsIn(tagIDs ...string) *Query {
+	return q.In("metadata.tags.sys.id", tagIDs)
+}
+
+//TagsAll matches entities linked to all of the given tags
+func (q *Query) TagsAll(tagIDs ...string) *Query {
+	return q.All("metadata.tags.sys.id", tagIDs)
+}
+
+//TagsExist matches entities with (or, when exists is false, without) any tag
+func (q *Query) TagsExist(exists bool) *Query {
+	if exists {
+		return q.Exists("metadata.tags")
+	}
+
+	return q.NotExists("metadata.tags")
+}
+
 //Exists [exists] query
 func (q *Query) Exists(field string) *Query {
 	q.exists = append(q.exists, field)
@@ -151,6 +246,7 @@ func (q *Query) GreaterThanOrEqual(field string, value interface{}) *Query {
 //Query param
 func (q *Query) Query(qStr string) *Query {
 	q.q = qStr
+	q.set["query"] = true
 	return q
 }
 
@@ -160,21 +256,48 @@ func (q *Query) Match(field, match string) *Query {
 	return q
 }
 
-//Near param
-func (q *Query) Near(field string, lat, lon int16) *Query {
-	q.near[field] = strconv.Itoa(int(lat)) + "," + strconv.Itoa(int(lon))
+//Range matches values between from and to, both included. It is a shorthand for [gte] and [lte].
+func (q *Query) Range(field string, from, to interface{}) *Query {
+	return q.GreaterThanOrEqual(field, from).LessThanOrEqual(field, to)
+}
+
+//Near param orders by the distance to the coordinates
+func (q *Query) Near(field string, lat, lon float64) *Query {
+	q.near[field] = []float64{lat, lon}
+	delete(q.geo, field+"[near]")
 	return q
 }
 
-//Within param
-func (q *Query) Within(field string, lat1, lon1, lat2, lon2 int16) *Query {
-	q.within[field] = strconv.Itoa(int(lat1)) + "," + strconv.Itoa(int(lon1)) + "," + strconv.Itoa(int(lat2)) + "," + strconv.Itoa(int(lon2))
+//Within param matches locations in the rectangle of the two corners
+func (q *Query) Within(field string, lat1, lon1, lat2, lon2 float64) *Query {
+	q.within[field] = []float64{lat1, lon1, lat2, lon2}
+	delete(q.geo, field+"[within]")
 	return q
 }
 
-//WithinRadius param
-func (q *Query) WithinRadius(field string, lat1, lon1, radius int16) *Query {
-	q.within[field] = strconv.Itoa(int(lat1)) + "," + strconv.Itoa(int(lon1)) + "," + strconv.Itoa(int(radius))
+//WithinRadius param matches locations in the circle, the radius is in kilometers
+func (q *Query) WithinRadius(field string, lat, lon, radius float64) *Query {
+	q.within[field] = []float64{lat, lon, radius}
+	delete(q.geo, field+"[within]")
+	return q
+}
+
+//LinkedContentType matches entries whose field links to an entry of the content type
+func (q *Query) LinkedContentType(field, contentType string) *Query {
+	return q.Equal(field+".sys.contentType.sys.id", contentType)
+}
+
+//LinksToEntry matches entities linking to the entry
+func (q *Query) LinksToEntry(entryID string) *Query {
+	q.linksEntry = entryID
+	q.set["links_to_entry"] = true
+	return q
+}
+
+//LinksToAsset matches entities linking to the asset
+func (q *Query) LinksToAsset(assetID string) *Query {
+	q.linksAsset = assetID
+	q.set["links_to_asset"] = true
 	return q
 }
 
@@ -192,101 +315,304 @@ func (q *Query) Order(field string, reverse bool) *Query {
 //Limit query
 func (q *Query) Limit(limit uint16) *Query {
 	q.limit = limit
+	q.set["limit"] = true
 	return q
 }
 
 //Skip query
 func (q *Query) Skip(skip uint16) *Query {
 	q.skip = skip
+	q.set["skip"] = true
 	return q
 }
 
 //MimeType query
 func (q *Query) MimeType(mime string) *Query {
 	q.mime = mime
+	q.set["mimetype_group"] = true
 	return q
 }
 
 //Locale query
 func (q *Query) Locale(locale string) *Query {
 	q.locale = locale
+	q.set["locale"] = true
 	return q
 }
 
-// Values constructs url.Values
-func (q *Query) Values() url.Values {
+//AllLocales query returns the fields of every locale
+func (q *Query) AllLocales() *Query {
+	return q.Locale(LocaleAll)
+}
+
+// FieldQuery binds the operators of a query to a QueryField
+type FieldQuery struct {
+	query *Query
+	field string
+}
+
+//Where returns the operators of the field, e.g. q.Where(QueryFieldSysID).In("id1", "id2")
+func (q *Query) Where(field QueryField) *FieldQuery {
+	return &FieldQuery{query: q, field: string(field)}
+}
+
+//OrderBy orders by a QueryField
+func (q *Query) OrderBy(field QueryField, reverse bool) *Query {
+	return q.Order(string(field), reverse)
+}
+
+//Equal equality query
+func (f *FieldQuery) Equal(value interface{}) *Query {
+	return f.query.Equal(f.field, value)
+}
+
+//NotEqual [ne] query
+func (f *FieldQuery) NotEqual(value interface{}) *Query {
+	return f.query.NotEqual(f.field, value)
+}
+
+//All [all] query
+func (f *FieldQuery) All(values ...interface{}) *Query {
+	f.query.all[f.field] = values
+	return f.query
+}
+
+//In [in] query
+func (f *FieldQuery) In(values ...interface{}) *Query {
+	f.query.in[f.field] = values
+	return f.query
+}
+
+//NotIn [nin] query
+func (f *FieldQuery) NotIn(values ...interface{}) *Query {
+	f.query.nin[f.field] = values
+	return f.query
+}
+
+//Exists [exists] query
+func (f *FieldQuery) Exists() *Query {
+	return f.query.Exists(f.field)
+}
+
+//NotExists [exists] query
+func (f *FieldQuery) NotExists() *Query {
+	return f.query.NotExists(f.field)
+}
+
+//LessThan [lt] query
+func (f *FieldQuery) LessThan(value interface{}) *Query {
+	return f.query.LessThan(f.field, value)
+}
+
+//LessThanOrEqual [lte] query
+func (f *FieldQuery) LessThanOrEqual(value interface{}) *Query {
+	return f.query.LessThanOrEqual(f.field, value)
+}
+
+//GreaterThan [gt] query
+func (f *FieldQuery) GreaterThan(value interface{}) *Query {
+	return f.query.GreaterThan(f.field, value)
+}
+
+//GreaterThanOrEqual [gte] query
+func (f *FieldQuery) GreaterThanOrEqual(value interface{}) *Query {
+	return f.query.GreaterThanOrEqual(f.field, value)
+}
+
+//Match [match] query
+func (f *FieldQuery) Match(match string) *Query {
+	return f.query.Match(f.field, match)
+}
+
+//Range matches values between from and to, both included
+func (f *FieldQuery) Range(from, to interface{}) *Query {
+	return f.query.Range(f.field, from, to)
+}
+
+//Near param
+func (f *FieldQuery) Near(lat, lon float64) *Query {
+	return f.query.Near(f.field, lat, lon)
+}
+
+//Within param
+func (f *FieldQuery) Within(lat1, lon1, lat2, lon2 float64) *Query {
+	return f.query.Within(f.field, lat1, lon1, lat2, lon2)
+}
+
+//WithinRadius param
+func (f *FieldQuery) WithinRadius(lat, lon, radius float64) *Query {
+	return f.query.WithinRadius(f.field, lat, lon, radius)
+}
+
+//LinkedContentType matches entries whose field links to an entry of the content type
+func (f *FieldQuery) LinkedContentType(contentType string) *Query {
+	return f.query.LinkedContentType(f.field, contentType)
+}
+
+// formatValue encodes a single operator value, time.Time values are converted to UTC and use the queryTimeLayout
+func formatValue(value interface{}) (string, error) {
+	if t, ok := value.(time.Time); ok {
+		return t.UTC().Format(queryTimeLayout), nil
+	}
+
+	if value == nil {
+		return "", fmt.Errorf("query values can not be nil")
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64), nil
+	}
+
+	return "", fmt.Errorf("unsupported query value type %T", value)
+}
+
+// formatValues encodes the values of a list operator such as [in]
+func formatValues(values []interface{}) (string, error) {
+	list := make([]string, len(values))
+	for i, value := range values {
+		formatted, err := formatValue(value)
+		if err != nil {
+			return "", err
+		}
+
+		list[i] = formatted
+	}
+
+	return strings.Join(list, ","), nil
+}
+
+// formatCoordinates encodes the numbers of a geo operator, or returns the parameter read by ParseQuery
+func (q *Query) formatCoordinates(key string, numbers []float64) string {
+	if raw, ok := q.geo[key]; ok {
+		return raw
+	}
+
+	list := make([]string, len(numbers))
+	for i, number := range numbers {
+		list[i] = strconv.FormatFloat(number, 'f', -1, 64)
+	}
+
+	return strings.Join(list, ",")
+}
+
+// validateCoordinates checks the latitude and longitude pairs of a geo operator,
+// a trailing odd number is the radius of WithinRadius
+func validateCoordinates(field string, numbers []float64) error {
+	for i := 0; i+1 < len(numbers); i += 2 {
+		if numbers[i] < -90 || numbers[i] > 90 {
+			return fmt.Errorf("%s: latitude %v should be between -90 and 90", field, numbers[i])
+		}
+
+		if numbers[i+1] < -180 || numbers[i+1] > 180 {
+			return fmt.Errorf("%s: longitude %v should be between -180 and 180", field, numbers[i+1])
+		}
+	}
+
+	if len(numbers) == 3 && numbers[2] <= 0 {
+		return fmt.Errorf("%s: radius should be positive", field)
+	}
+
+	return nil
+}
+
+// sortedKeys returns the keys of a map with string keys in order, so that queries are built
+// and validated the same way on every call
+func sortedKeys(m interface{}) []string {
+	keys := []string{}
+	for _, key := range reflect.ValueOf(m).MapKeys() {
+		keys = append(keys, key.String())
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// Build constructs the url.Values of the query, or returns an error for invalid queries.
+// Fields are visited in order, Encode of the values is stable between calls.
+func (q *Query) Build() (url.Values, error) {
 	params := url.Values{}
 
-	if q.include != 0 {
+	if q.include != 0 || q.set["include"] {
 		if q.include > 10 {
-			panic("include value should be between 0 and 10")
+			return nil, fmt.Errorf("include value should be between 0 and 10")
 		}
 
 		params.Set("include", strconv.Itoa(int(q.include)))
 	}
 
-	if q.contentType != "" {
+	if q.contentType != "" || q.set["content_type"] {
 		params.Set("content_type", q.contentType)
 	}
 
 	if len(q.fields) > 0 {
 		if len(q.fields) > 100 {
-			panic("You can select up to 100 properties for `select`")
+			return nil, fmt.Errorf("you can select up to 100 properties for `select`")
 		}
 
 		for _, sel := range q.fields {
 			if len(strings.Split(sel, ".")) > 2 {
-				panic("you should provide at most 2 depth for `select`")
+				return nil, fmt.Errorf("you should provide at most 2 depth for `select`")
 			}
 		}
 
 		if q.contentType == "" {
-			panic("you should provide content_type parameter")
+			return nil, fmt.Errorf("you should provide content_type parameter")
 		}
 
 		params.Set("select", strings.Join(q.fields, ","))
 	}
 
-	for k, v := range q.e {
-		switch reflect.TypeOf(v).Kind() {
-		case reflect.Int:
-			{
-				intV := reflect.ValueOf(v).Interface().(int)
-				params.Set(k, strconv.Itoa(intV))
-			}
-		case reflect.String:
-			{
-				strV := reflect.ValueOf(v).Interface().(string)
-				params.Set(k, strV)
-			}
-		}
+	operators := []struct {
+		suffix string
+		values map[string]interface{}
+	}{
+		{"", q.e},
+		{"[ne]", q.ne},
+		{"[lt]", q.lt},
+		{"[lte]", q.lte},
+		{"[gt]", q.gt},
+		{"[gte]", q.gte},
 	}
 
-	for k, v := range q.ne {
-		switch reflect.TypeOf(v).Kind() {
-		case reflect.Int:
-			{
-				intV := reflect.ValueOf(v).Interface().(int)
-				params.Set(k+"[ne]", strconv.Itoa(intV))
-			}
-		case reflect.String:
-			{
-				strV := reflect.ValueOf(v).Interface().(string)
-				params.Set(k+"[ne]", strV)
+	for _, operator := range operators {
+		for _, k := range sortedKeys(operator.values) {
+			value, err := formatValue(operator.values[k])
+			if err != nil {
+				return nil, fmt.Errorf("%s%s: %s", k, operator.suffix, err)
 			}
+
+			params.Set(k+operator.suffix, value)
 		}
 	}
 
-	for k, v := range q.all {
-		params.Set(k+"[all]", strings.Join(v, ","))
+	listOperators := []struct {
+		suffix string
+		values map[string][]interface{}
+	}{
+		{"[all]", q.all},
+		{"[in]", q.in},
+		{"[nin]", q.nin},
 	}
 
-	for k, v := range q.in {
-		params.Set(k+"[in]", strings.Join(v, ","))
-	}
+	for _, operator := range listOperators {
+		for _, k := range sortedKeys(operator.values) {
+			value, err := formatValues(operator.values[k])
+			if err != nil {
+				return nil, fmt.Errorf("%s%s: %s", k, operator.suffix, err)
+			}
 
-	for k, v := range q.nin {
-		params.Set(k+"[nin]", strings.Join(v, ","))
+			params.Set(k+operator.suffix, value)
+		}
 	}
 
 	for _, v := range q.exists {
@@ -297,113 +623,245 @@ func (q *Query) Values() url.Values {
 		params.Set(v+"[exists]", "false")
 	}
 
-	for k, v := range q.lt {
-		switch reflect.TypeOf(v).Kind() {
-		case reflect.Int:
-			{
-				intV := reflect.ValueOf(v).Interface().(int)
-				params.Set(k+"[lt]", strconv.Itoa(intV))
-			}
-		case reflect.Struct:
-			{
-				timeV := reflect.ValueOf(v).Interface().(time.Time)
-				params.Set(k+"[lt]", timeV.Format("2006-01-02 15:04:05"))
-			}
-		}
+	if q.q != "" || q.set["query"] {
+		params.Set("query", q.q)
 	}
 
-	for k, v := range q.lte {
-		switch reflect.TypeOf(v).Kind() {
-		case reflect.Int:
-			{
-				intV := reflect.ValueOf(v).Interface().(int)
-				params.Set(k+"[lte]", strconv.Itoa(intV))
-			}
-		case reflect.Struct:
-			{
-				timeV := reflect.ValueOf(v).Interface().(time.Time)
-				params.Set(k+"[lte]", timeV.Format("2006-01-02 15:04:05"))
-			}
-		}
+	for _, k := range sortedKeys(q.match) {
+		params.Set(k+"[match]", q.match[k])
 	}
 
-	for k, v := range q.gt {
-		switch reflect.TypeOf(v).Kind() {
-		case reflect.Int:
-			{
-				intV := reflect.ValueOf(v).Interface().(int)
-				params.Set(k+"[gt]", strconv.Itoa(intV))
-			}
-		case reflect.Struct:
-			{
-				timeV := reflect.ValueOf(v).Interface().(time.Time)
-				params.Set(k+"[gt]", timeV.Format("2006-01-02 15:04:05"))
-			}
+	for _, k := range sortedKeys(q.near) {
+		if err := validateCoordinates(k+"[near]", q.near[k]); err != nil {
+			return nil, err
 		}
+
+		params.Set(k+"[near]", q.formatCoordinates(k+"[near]", q.near[k]))
 	}
 
-	for k, v := range q.gte {
-		switch reflect.TypeOf(v).Kind() {
-		case reflect.Int:
-			{
-				intV := reflect.ValueOf(v).Interface().(int)
-				params.Set(k+"[gte]", strconv.Itoa(intV))
-			}
-		case reflect.Struct:
-			{
-				timeV := reflect.ValueOf(v).Interface().(time.Time)
-				params.Set(k+"[gte]", timeV.Format("2006-01-02 15:04:05"))
-			}
+	for _, k := range sortedKeys(q.within) {
+		if err := validateCoordinates(k+"[within]", q.within[k]); err != nil {
+			return nil, err
 		}
-	}
 
-	if q.q != "" {
-		params.Set("query", q.q)
+		params.Set(k+"[within]", q.formatCoordinates(k+"[within]", q.within[k]))
 	}
 
-	for k, v := range q.match {
-		params.Set(k+"[match]", v)
+	if q.linksEntry != "" || q.set["links_to_entry"] {
+		params.Set("links_to_entry", q.linksEntry)
 	}
 
-	for k, v := range q.near {
-		params.Set(k+"[near]", v)
+	if q.linksAsset != "" || q.set["links_to_asset"] {
+		params.Set("links_to_asset", q.linksAsset)
 	}
 
-	for k, v := range q.within {
-		params.Set(k+"[within]", v)
+	if q.contentType == "" {
+		for _, k := range sortedKeys(params) {
+			if isReferenceSearch(strings.SplitN(k, "[", 2)[0]) {
+				return nil, fmt.Errorf("%s: reference search requires a content_type parameter", k)
+			}
+		}
 	}
 
 	if len(q.order) > 0 {
-		// if q.contentType == "" {
-		// panic("you should provide a content type for order queries")
-		// }
-
 		params.Set("order", strings.Join(q.order, ","))
 	}
 
-	if q.limit != 0 {
+	if q.limit != 0 || q.set["limit"] {
 		if q.limit > 1000 {
-			panic("limit value should be between 0 and 1000")
+			return nil, fmt.Errorf("limit value should be between 0 and 1000")
 		}
 
 		params.Set("limit", strconv.Itoa(int(q.limit)))
 	}
 
-	if q.skip != 0 {
+	if q.skip != 0 || q.set["skip"] {
 		params.Set("skip", strconv.Itoa(int(q.skip)))
 	}
 
-	if q.mime != "" {
+	if q.mime != "" || q.set["mimetype_group"] {
 		params.Set("mimetype_group", q.mime)
 	}
 
-	if q.locale != "" {
+	if q.locale != "" || q.set["locale"] {
 		params.Set("locale", q.locale)
 	}
 
+	return params, nil
+}
+
+// Values constructs url.Values, it panics for invalid queries. Valid queries never panic.
+//
+// Deprecated: use Build, which returns an error for invalid queries instead of panicking.
+func (q *Query) Values() url.Values {
+	params, err := q.Build()
+	if err != nil {
+		panic(err.Error())
+	}
+
 	return params
 }
 
+// String encodes the query, it panics for invalid queries like Values.
+// Use Build to check a query first.
 func (q *Query) String() string {
 	return q.Values().Encode()
 }
+
+// queryOperators are the [operator] suffixes of query parameters
+var queryOperators = map[string]bool{
+	"ne": true, "all": true, "in": true, "nin": true, "exists": true,
+	"lt": true, "lte": true, "gt": true, "gte": true,
+	"match": true, "near": true, "within": true,
+}
+
+func parseUint16(key, value string) (uint16, error) {
+	number, err := strconv.ParseUint(value, 10, 16)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %q is not a valid number", key, value)
+	}
+
+	return uint16(number), nil
+}
+
+func parseCoordinates(key, value string) ([]float64, error) {
+	numbers := []float64{}
+	for _, part := range strings.Split(value, ",") {
+		number, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %q is not a valid coordinate", key, part)
+		}
+
+		numbers = append(numbers, number)
+	}
+
+	return numbers, nil
+}
+
+// ParseQuery reads url query parameters, e.g. of a saved search, back into a Query.
+// Values are kept as strings, so the query encodes to the same parameters through Query.Build.
+func ParseQuery(values url.Values) (*Query, error) {
+	q := NewQuery()
+
+	for _, key := range sortedKeys(values) {
+		if len(values[key]) != 1 {
+			return nil, fmt.Errorf("%s: expected a single value, got %d", key, len(values[key]))
+		}
+
+		value := values[key][0]
+
+		switch key {
+		case "include":
+			include, err := parseUint16(key, value)
+			if err != nil {
+				return nil, err
+			}
+			q.Include(include)
+			continue
+		case "limit":
+			limit, err := parseUint16(key, value)
+			if err != nil {
+				return nil, err
+			}
+			q.Limit(limit)
+			continue
+		case "skip":
+			skip, err := parseUint16(key, value)
+			if err != nil {
+				return nil, err
+			}
+			q.Skip(skip)
+			continue
+		case "content_type":
+			q.ContentType(value)
+			continue
+		case "select":
+			q.Select(strings.Split(value, ","))
+			continue
+		case "query":
+			q.Query(value)
+			continue
+		case "order":
+			q.order = strings.Split(value, ",")
+			continue
+		case "mimetype_group":
+			q.MimeType(value)
+			continue
+		case "locale":
+			q.Locale(value)
+			continue
+		case "links_to_entry":
+			q.LinksToEntry(value)
+			continue
+		case "links_to_asset":
+			q.LinksToAsset(value)
+			continue
+		}
+
+		field, operator := key, ""
+		if open := strings.LastIndex(key, "["); open > 0 && strings.HasSuffix(key, "]") {
+			field, operator = key[:open], key[open+1:len(key)-1]
+			if !queryOperators[operator] {
+				return nil, fmt.Errorf("%s: unknown operator [%s]", key, operator)
+			}
+		}
+
+		switch operator {
+		case "":
+			q.Equal(field, value)
+		case "ne":
+			q.NotEqual(field, value)
+		case "all":
+			q.All(field, strings.Split(value, ","))
+		case "in":
+			q.In(field, strings.Split(value, ","))
+		case "nin":
+			q.NotIn(field, strings.Split(value, ","))
+		case "exists":
+			switch value {
+			case "true":
+				q.Exists(field)
+			case "false":
+				q.NotExists(field)
+			default:
+				return nil, fmt.Errorf("%s: expected true or false, got %q", key, value)
+			}
+		case "lt":
+			q.LessThan(field, value)
+		case "lte":
+			q.LessThanOrEqual(field, value)
+		case "gt":
+			q.GreaterThan(field, value)
+		case "gte":
+			q.GreaterThanOrEqual(field, value)
+		case "match":
+			q.Match(field, value)
+		case "near":
+			numbers, err := parseCoordinates(key, value)
+			if err != nil {
+				return nil, err
+			}
+			if len(numbers) != 2 {
+				return nil, fmt.Errorf("%s: expected latitude and longitude, got %d numbers", key, len(numbers))
+			}
+			q.near[field] = numbers
+			q.geo[key] = value
+		case "within":
+			numbers, err := parseCoordinates(key, value)
+			if err != nil {
+				return nil, err
+			}
+			if len(numbers) != 3 && len(numbers) != 4 {
+				return nil, fmt.Errorf("%s: expected a circle or a rectangle, got %d numbers", key, len(numbers))
+			}
+			q.within[field] = numbers
+			q.geo[key] = value
+		}
+	}
+
+	if _, err := q.Build(); err != nil {
+		return nil, err
+	}
+
+	return q, nil
+}
@@ -0,0 +1,794 @@
+package contentful
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// queryDefaultLimit is the page size of the api when a query has no limit
+const queryDefaultLimit = 100
+
+// QueryEvaluator answers queries over local entries and assets, e.g. of a snapshot,
+// with the semantics the api uses to answer them remotely.
+//
+// Links are resolved among the given entities for reference search. The include parameter
+// is ignored, and the returned entities keep the values of every locale.
+type QueryEvaluator struct {
+	// DefaultLocale is the locale of field values read when the query has no locale or
+	// queries all of them, it defaults to the default of the Locales or en-US
+	DefaultLocale string
+
+	// Locales of the space, fields without a value in the locale of the query are read in its
+	// fallback locales. Without them the fallback of every locale is the default locale.
+	Locales []*Locale
+}
+
+// queryDocument is the json form of an entity with the field values of a single locale
+type queryDocument map[string]interface{}
+
+type queryRun struct {
+	q         *Query
+	documents map[string]queryDocument
+}
+
+// Entries returns the page of entries matching the query and the total number of matches
+func (evaluator QueryEvaluator) Entries(q *Query, entries []*Entry) ([]*Entry, int, error) {
+	locales := evaluator.locales(q)
+
+	documents := make([]queryDocument, len(entries))
+	for i, entry := range entries {
+		documents[i] = entryDocument(entry, locales)
+	}
+
+	indexes, total, err := evaluator.evaluate(q, documents)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	results := []*Entry{}
+	for _, i := range indexes {
+		results = append(results, selectEntryFields(q, entries[i]))
+	}
+
+	return results, total, nil
+}
+
+// Assets returns the page of assets matching the query and the total number of matches
+func (evaluator QueryEvaluator) Assets(q *Query, assets []*Asset) ([]*Asset, int, error) {
+	locales := evaluator.locales(q)
+
+	documents := make([]queryDocument, len(assets))
+	for i, asset := range assets {
+		documents[i] = assetDocument(asset, locales)
+	}
+
+	indexes, total, err := evaluator.evaluate(q, documents)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	results := []*Asset{}
+	for _, i := range indexes {
+		results = append(results, selectAssetFields(q, assets[i]))
+	}
+
+	return results, total, nil
+}
+
+func (evaluator QueryEvaluator) defaultLocale() string {
+	if evaluator.DefaultLocale != "" {
+		return evaluator.DefaultLocale
+	}
+
+	for _, locale := range evaluator.Locales {
+		if locale.Default {
+			return locale.Code
+		}
+	}
+
+	return "en-US"
+}
+
+// locales returns the locale of the query followed by its fallback chain
+func (evaluator QueryEvaluator) locales(q *Query) []string {
+	locale := q.locale
+	if locale == "" || locale == LocaleAll {
+		locale = evaluator.defaultLocale()
+	}
+
+	if len(evaluator.Locales) == 0 {
+		if locale == evaluator.defaultLocale() {
+			return []string{locale}
+		}
+
+		return []string{locale, evaluator.defaultLocale()}
+	}
+
+	fallbacks := map[string]string{}
+	for _, l := range evaluator.Locales {
+		fallbacks[l.Code] = l.FallbackCode
+	}
+
+	chain := []string{locale}
+	seen := map[string]bool{locale: true}
+	for code := fallbacks[locale]; code != "" && !seen[code]; code = fallbacks[code] {
+		chain = append(chain, code)
+		seen[code] = true
+	}
+
+	return chain
+}
+
+// evaluate returns the positions of the documents of the requested page, in order
+func (evaluator QueryEvaluator) evaluate(q *Query, documents []queryDocument) ([]int, int, error) {
+	if _, err := q.Build(); err != nil {
+		return nil, 0, err
+	}
+
+	if len(q.near) > 1 {
+		return nil, 0, fmt.Errorf("only one field can be ordered by [near]")
+	}
+
+	if len(q.near) > 0 && len(q.order) > 0 {
+		return nil, 0, fmt.Errorf("[near] can not be combined with order")
+	}
+
+	run := &queryRun{q: q, documents: map[string]queryDocument{}}
+	for _, document := range documents {
+		if id, ok := lookupString(document, "sys", "id"); ok {
+			run.documents[id] = document
+		}
+	}
+
+	matches := []int{}
+	for i, document := range documents {
+		ok, err := run.match(document)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if ok {
+			matches = append(matches, i)
+		}
+	}
+
+	run.sort(matches, documents)
+
+	total := len(matches)
+
+	skip := int(q.skip)
+	if skip > len(matches) {
+		skip = len(matches)
+	}
+	matches = matches[skip:]
+
+	// an explicit limit of zero only counts the matches
+	limit := int(q.limit)
+	if limit == 0 && !q.set["limit"] {
+		limit = queryDefaultLimit
+	}
+	if limit < len(matches) {
+		matches = matches[:limit]
+	}
+
+	return matches, total, nil
+}
+
+// entryDocument reads the entry into a document, localized fields hold the value of the first
+// of the locales with one
+func entryDocument(entry *Entry, locales []string) queryDocument {
+	document := queryDocument{}
+	document["sys"] = jsonValue(entry.Sys)
+	document["metadata"] = jsonValue(entry.Metadata)
+
+	fields := map[string]interface{}{}
+	for id, value := range entry.Fields {
+		// single locale responses hold the values as is
+		if entry.Sys != nil && entry.Sys.Locale != "" {
+			fields[id] = jsonValue(value)
+			continue
+		}
+
+		localized, ok := jsonValue(value).(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		for _, locale := range locales {
+			if localeValue, ok := localized[locale]; ok {
+				fields[id] = localeValue
+				break
+			}
+		}
+	}
+	document["fields"] = fields
+
+	return document
+}
+
+// assetDocument reads the asset into a document, the fields hold the value of the first
+// of the locales with one
+func assetDocument(asset *Asset, locales []string) queryDocument {
+	document := queryDocument{}
+	document["sys"] = jsonValue(asset.Sys)
+	document["metadata"] = jsonValue(asset.Metadata)
+
+	fields := map[string]interface{}{}
+	if asset.Fields != nil {
+		for i := len(locales) - 1; i >= 0; i-- {
+			locale := locales[i]
+
+			if title, ok := asset.Fields.Title[locale]; ok {
+				fields["title"] = title
+			}
+
+			if description, ok := asset.Fields.Description[locale]; ok {
+				fields["description"] = description
+			}
+
+			if file, ok := asset.Fields.File[locale]; ok && file != nil {
+				fields["file"] = jsonValue(file)
+			}
+		}
+	}
+	document["fields"] = fields
+
+	return document
+}
+
+// jsonValue returns the value as decoded from its json, with float64 numbers and string dates
+func jsonValue(value interface{}) interface{} {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil
+	}
+
+	return decoded
+}
+
+func lookupString(document map[string]interface{}, path ...string) (string, bool) {
+	var value interface{} = document
+	for _, key := range path {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return "", false
+		}
+		value = m[key]
+	}
+
+	s, ok := value.(string)
+	return s, ok
+}
+
+// isLink reports whether the value is a link to an entity
+func isLink(value interface{}) bool {
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return false
+	}
+
+	linkType, _ := lookupString(m, "sys", "type")
+	return linkType == "Link"
+}
+
+// values returns the values at the path of the document. Arrays are flattened, so the values
+// of an array field are its items, and links are followed into the linked documents.
+func (run *queryRun) values(document queryDocument, path string) []interface{} {
+	current := []interface{}{map[string]interface{}(document)}
+
+	for _, key := range strings.Split(path, ".") {
+		next := []interface{}{}
+		for _, value := range current {
+			m, ok := value.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			child, ok := m[key]
+			if isLink(m) {
+				// reference search continues in the linked entity
+				id, _ := lookupString(m, "sys", "id")
+				if linked, found := run.documents[id]; found {
+					child, ok = linked[key]
+				}
+			}
+
+			if !ok || child == nil {
+				continue
+			}
+
+			if list, isList := child.([]interface{}); isList {
+				next = append(next, list...)
+			} else {
+				next = append(next, child)
+			}
+		}
+		current = next
+	}
+
+	return current
+}
+
+func (run *queryRun) match(document queryDocument) (bool, error) {
+	q := run.q
+
+	if q.contentType != "" {
+		if id, _ := lookupString(document, "sys", "contentType", "sys", "id"); id != q.contentType {
+			return false, nil
+		}
+	}
+
+	if q.mime != "" {
+		contentType, _ := lookupString(document, "fields", "file", "contentType")
+		if contentType == "" || MimeTypeGroup(contentType) != q.mime {
+			return false, nil
+		}
+	}
+
+	if q.linksEntry != "" && !linksTo(document["fields"], "Entry", q.linksEntry) {
+		return false, nil
+	}
+
+	if q.linksAsset != "" && !linksTo(document["fields"], "Asset", q.linksAsset) {
+		return false, nil
+	}
+
+	if q.q != "" && !matchText(textOf(document["fields"]), q.q) {
+		return false, nil
+	}
+
+	// fields are visited in order, so the error of an invalid query is always the same
+	for _, field := range sortedKeys(q.e) {
+		ok, err := run.any(document, field, q.e[field], func(c int) bool { return c == 0 })
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+
+	for _, field := range sortedKeys(q.ne) {
+		ok, err := run.any(document, field, q.ne[field], func(c int) bool { return c == 0 })
+		if err != nil || ok {
+			return false, err
+		}
+	}
+
+	comparisons := []struct {
+		values map[string]interface{}
+		accept func(int) bool
+	}{
+		{q.lt, func(c int) bool { return c < 0 }},
+		{q.lte, func(c int) bool { return c <= 0 }},
+		{q.gt, func(c int) bool { return c > 0 }},
+		{q.gte, func(c int) bool { return c >= 0 }},
+	}
+
+	for _, comparison := range comparisons {
+		for _, field := range sortedKeys(comparison.values) {
+			ok, err := run.any(document, field, comparison.values[field], comparison.accept)
+			if err != nil || !ok {
+				return false, err
+			}
+		}
+	}
+
+	for _, field := range sortedKeys(q.in) {
+		ok, err := run.anyOf(document, field, q.in[field])
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+
+	for _, field := range sortedKeys(q.nin) {
+		ok, err := run.anyOf(document, field, q.nin[field])
+		if err != nil || ok {
+			return false, err
+		}
+	}
+
+	for _, field := range sortedKeys(q.all) {
+		for _, value := range q.all[field] {
+			ok, err := run.any(document, field, value, func(c int) bool { return c == 0 })
+			if err != nil || !ok {
+				return false, err
+			}
+		}
+	}
+
+	for _, field := range q.exists {
+		if len(run.values(document, field)) == 0 {
+			return false, nil
+		}
+	}
+
+	for _, field := range q.notExists {
+		if len(run.values(document, field)) > 0 {
+			return false, nil
+		}
+	}
+
+	for _, field := range sortedKeys(q.match) {
+		if !matchText(textOf(run.values(document, field)), q.match[field]) {
+			return false, nil
+		}
+	}
+
+	for _, field := range sortedKeys(q.within) {
+		if !run.within(document, field, q.within[field]) {
+			return false, nil
+		}
+	}
+
+	for _, field := range sortedKeys(q.near) {
+		if len(locations(run.values(document, field))) == 0 {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// any reports whether a value at the field compares to the query value as accepted
+func (run *queryRun) any(document queryDocument, field string, value interface{}, accept func(int) bool) (bool, error) {
+	query, err := formatValue(value)
+	if err != nil {
+		return false, fmt.Errorf("%s: %s", field, err)
+	}
+
+	for _, actual := range run.values(document, field) {
+		if c, ok := compareQueryValue(actual, query); ok && accept(c) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// anyOf reports whether a value at the field equals one of the query values
+func (run *queryRun) anyOf(document queryDocument, field string, values []interface{}) (bool, error) {
+	for _, value := range values {
+		ok, err := run.any(document, field, value, func(c int) bool { return c == 0 })
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+
+	return false, nil
+}
+
+// compareQueryValue compares a document value to an encoded query value, as numbers,
+// booleans, dates or strings depending on the document value
+func compareQueryValue(actual interface{}, query string) (int, bool) {
+	switch actual := actual.(type) {
+	case float64:
+		number, err := strconv.ParseFloat(query, 64)
+		if err != nil {
+			return 0, false
+		}
+		return compareFloats(actual, number), true
+	case bool:
+		expected, err := strconv.ParseBool(query)
+		if err != nil {
+			return 0, false
+		}
+		if actual == expected {
+			return 0, true
+		}
+		if actual {
+			return 1, true
+		}
+		return -1, true
+	case string:
+		if actualTime, ok := parseQueryTime(actual); ok {
+			if queryTime, ok := parseQueryTime(query); ok {
+				return compareFloats(float64(actualTime.UnixNano()), float64(queryTime.UnixNano())), true
+			}
+		}
+		return strings.Compare(actual, query), true
+	}
+
+	return 0, false
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
+
+// parseQueryTime reads the dates of documents and queries, times without a zone are in UTC
+func parseQueryTime(value string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339Nano, queryTimeLayout, FieldValidationDateLayout, "2006-01-02T15:04", "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// textOf returns the texts of the values, including the texts of rich text documents
+func textOf(value interface{}) []string {
+	texts := []string{}
+
+	switch value := value.(type) {
+	case string:
+		texts = append(texts, value)
+	case []interface{}:
+		for _, item := range value {
+			texts = append(texts, textOf(item)...)
+		}
+	case map[string]interface{}:
+		if isLink(value) {
+			return texts
+		}
+		for key, item := range value {
+			// only the text of rich text nodes is searchable, not their types
+			if nodeType, ok := value["nodeType"]; ok && nodeType != nil && key != "value" && key != "content" {
+				continue
+			}
+			texts = append(texts, textOf(item)...)
+		}
+	}
+
+	return texts
+}
+
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// matchText reports whether every word of the query starts a word of the texts,
+// the full text search of the api
+func matchText(texts []string, query string) bool {
+	queryWords := words(query)
+	if len(queryWords) == 0 {
+		return false
+	}
+
+	textWords := []string{}
+	for _, text := range texts {
+		textWords = append(textWords, words(text)...)
+	}
+
+	for _, queryWord := range queryWords {
+		found := false
+		for _, textWord := range textWords {
+			if strings.HasPrefix(textWord, queryWord) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// linksTo reports whether the value holds a link to the entity, including the targets of rich text
+func linksTo(value interface{}, linkType, id string) bool {
+	switch value := value.(type) {
+	case []interface{}:
+		for _, item := range value {
+			if linksTo(item, linkType, id) {
+				return true
+			}
+		}
+	case map[string]interface{}:
+		if isLink(value) {
+			linkID, _ := lookupString(value, "sys", "id")
+			actualType, _ := lookupString(value, "sys", "linkType")
+			return linkID == id && actualType == linkType
+		}
+
+		for _, item := range value {
+			if linksTo(item, linkType, id) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// locations returns the lat and lon of the location values
+func locations(values []interface{}) [][2]float64 {
+	points := [][2]float64{}
+	for _, value := range values {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		lat, latOK := m["lat"].(float64)
+		lon, lonOK := m["lon"].(float64)
+		if latOK && lonOK {
+			points = append(points, [2]float64{lat, lon})
+		}
+	}
+
+	return points
+}
+
+// distance returns the great circle distance between two points in kilometers
+func distance(lat1, lon1, lat2, lon2 float64) float64 {
+	const earthRadius = 6371.0
+
+	toRadians := func(degrees float64) float64 { return degrees * math.Pi / 180 }
+	dLat := toRadians(lat2 - lat1)
+	dLon := toRadians(lon2 - lon1)
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRadians(lat1))*math.Cos(toRadians(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// within reports whether a location of the field is in the circle or rectangle
+func (run *queryRun) within(document queryDocument, field string, numbers []float64) bool {
+	for _, point := range locations(run.values(document, field)) {
+		if len(numbers) == 3 {
+			if distance(numbers[0], numbers[1], point[0], point[1]) <= numbers[2] {
+				return true
+			}
+			continue
+		}
+
+		minLat, maxLat := math.Min(numbers[0], numbers[2]), math.Max(numbers[0], numbers[2])
+		minLon, maxLon := math.Min(numbers[1], numbers[3]), math.Max(numbers[1], numbers[3])
+		if point[0] >= minLat && point[0] <= maxLat && point[1] >= minLon && point[1] <= maxLon {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sort orders the matches by distance for [near], or by the order of the query.
+// Documents without a value are last, ties keep the order of the given entities.
+func (run *queryRun) sort(matches []int, documents []queryDocument) {
+	for field, numbers := range run.q.near {
+		nearest := func(i int) float64 {
+			best := math.Inf(1)
+			for _, point := range locations(run.values(documents[i], field)) {
+				best = math.Min(best, distance(numbers[0], numbers[1], point[0], point[1]))
+			}
+			return best
+		}
+
+		sort.SliceStable(matches, func(a, b int) bool {
+			return nearest(matches[a]) < nearest(matches[b])
+		})
+		return
+	}
+
+	if len(run.q.order) == 0 {
+		return
+	}
+
+	sort.SliceStable(matches, func(a, b int) bool {
+		for _, order := range run.q.order {
+			field, reverse := strings.TrimPrefix(order, "-"), strings.HasPrefix(order, "-")
+
+			c := run.compareDocuments(documents[matches[a]], documents[matches[b]], field)
+			if c == 0 {
+				continue
+			}
+
+			// missing values are last in both directions
+			if c == 2 || c == -2 {
+				return c < 0
+			}
+
+			if reverse {
+				return c > 0
+			}
+			return c < 0
+		}
+
+		return false
+	})
+}
+
+// compareDocuments compares the first values of the field, it returns -2 or 2 when only one
+// of the documents has a value
+func (run *queryRun) compareDocuments(a, b queryDocument, field string) int {
+	valuesA, valuesB := run.values(a, field), run.values(b, field)
+
+	switch {
+	case len(valuesA) == 0 && len(valuesB) == 0:
+		return 0
+	case len(valuesA) == 0:
+		return 2
+	case len(valuesB) == 0:
+		return -2
+	}
+
+	query, err := formatValue(valuesB[0])
+	if err != nil {
+		return 0
+	}
+
+	c, _ := compareQueryValue(valuesA[0], query)
+	return c
+}
+
+// selectedFields returns the ids of the fields of select, nil when every field is returned
+func selectedFields(q *Query) map[string]bool {
+	if len(q.fields) == 0 {
+		return nil
+	}
+
+	ids := map[string]bool{}
+	for _, sel := range q.fields {
+		if sel == "fields" {
+			return nil
+		}
+
+		if strings.HasPrefix(sel, "fields.") {
+			ids[strings.TrimPrefix(sel, "fields.")] = true
+		}
+	}
+
+	return ids
+}
+
+// selectEntryFields returns a copy of the entry with the fields of select, sys is always returned
+func selectEntryFields(q *Query, entry *Entry) *Entry {
+	ids := selectedFields(q)
+	if ids == nil {
+		return entry
+	}
+
+	selected := *entry
+	selected.Fields = map[string]interface{}{}
+	for id, value := range entry.Fields {
+		if ids[id] {
+			selected.Fields[id] = value
+		}
+	}
+
+	return &selected
+}
+
+// selectAssetFields returns a copy of the asset with the fields of select, sys is always returned
+func selectAssetFields(q *Query, asset *Asset) *Asset {
+	ids := selectedFields(q)
+	if ids == nil || asset.Fields == nil {
+		return asset
+	}
+
+	selected := *asset
+	selected.Fields = &FileFields{}
+	if ids["title"] {
+		selected.Fields.Title = asset.Fields.Title
+	}
+	if ids["description"] {
+		selected.Fields.Description = asset.Fields.Description
+	}
+	if ids["file"] {
+		selected.Fields.File = asset.Fields.File
+	}
+
+	return &selected
+}
@@ -0,0 +1,237 @@
+package contentful
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func queryEntriesFromTestData() []*Entry {
+	var col Collection
+	json.Unmarshal([]byte(readTestData("query_entries.json")), &col)
+
+	return col.ToEntry()
+}
+
+func entryIDs(entries []*Entry) []string {
+	ids := []string{}
+	for _, entry := range entries {
+		ids = append(ids, entry.Sys.ID)
+	}
+
+	return ids
+}
+
+func TestQueryEvaluatorEntries(t *testing.T) {
+	assert := assert.New(t)
+	entries := queryEntriesFromTestData()
+	evaluator := QueryEvaluator{}
+
+	tests := []struct {
+		name     string
+		query    *Query
+		expected []string
+	}{
+		{"content type", NewQuery().ContentType("person"), []string{"jane", "john"}},
+		{"equal", NewQuery().ContentType("cat").Equal("fields.color", "gray"), []string{"happycat"}},
+		{"equal number", NewQuery().ContentType("cat").Equal("fields.lives", 9), []string{"garfield"}},
+		{"equal bool", NewQuery().ContentType("cat").Equal("fields.lifeIsGood", false), []string{"happycat"}},
+		{"equal array item", NewQuery().ContentType("cat").Equal("fields.likes", "fish"), []string{"nyancat", "garfield"}},
+		{"not equal", NewQuery().ContentType("cat").NotEqual("fields.color", "gray"), []string{"nyancat", "garfield"}},
+		{"in", NewQuery().In("sys.id", []string{"jane", "garfield", "unknown"}), []string{"garfield", "jane"}},
+		{"not in numbers", NewQuery().ContentType("cat").Where(FieldPath("lives")).NotIn(1, 9), []string{"nyancat"}},
+		{"all", NewQuery().ContentType("cat").All("fields.likes", []string{"fish", "lasagna"}), []string{"garfield"}},
+		{"exists", NewQuery().ContentType("cat").Exists("fields.bestFriend"), []string{"nyancat", "garfield"}},
+		{"not exists", NewQuery().NotExists("fields.bestFriend"), []string{"happycat", "jane", "john"}},
+		{"greater than", NewQuery().ContentType("cat").GreaterThan("fields.lives", 1), []string{"nyancat", "garfield"}},
+		{"less than or equal", NewQuery().ContentType("cat").LessThanOrEqual("fields.lives", 9), []string{"happycat", "garfield"}},
+		{"date range", NewQuery().ContentType("cat").
+			Range("fields.birthday", time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2005, 1, 1, 0, 0, 0, 0, time.UTC)),
+			[]string{"happycat"}},
+		{"sys dates", NewQuery().Where(QueryFieldSysUpdatedAt).GreaterThan("2013-09-01T00:00:00Z"), []string{"nyancat", "happycat"}},
+		{"tags", NewQuery().TagsIn("rainbow"), []string{"nyancat"}},
+		{"match", NewQuery().ContentType("cat").Match("fields.name", "cat"), []string{"nyancat", "happycat"}},
+		{"match prefix of array items", NewQuery().ContentType("cat").Match("fields.likes", "rainb"), []string{"nyancat"}},
+		{"match rich text", NewQuery().ContentType("person").Match("fields.bio", "sushi"), []string{"jane"}},
+		{"full text", NewQuery().Query("rainbows"), []string{"nyancat", "jane"}},
+		{"links to entry", NewQuery().LinksToEntry("garfield"), []string{"jane"}},
+		{"links to asset", NewQuery().LinksToAsset("nyancat-image"), []string{"nyancat"}},
+		{"reference search", NewQuery().ContentType("cat").Where(FieldPath("owner").Field("name")).Match("jane"), []string{"nyancat", "garfield"}},
+		{"reference content type", NewQuery().ContentType("cat").LinkedContentType("fields.bestFriend", "cat"), []string{"nyancat", "garfield"}},
+		{"within radius", NewQuery().ContentType("cat").WithinRadius("fields.location", 52.52, 13.40, 10), []string{"nyancat"}},
+		{"within rectangle", NewQuery().ContentType("cat").Within("fields.location", 40, 0, 50, 10), []string{"happycat"}},
+		{"locale", NewQuery().Locale("de-DE").ContentType("cat").Match("fields.name", "katze"), []string{"nyancat"}},
+		{"locale fallback", NewQuery().Locale("de-DE").ContentType("cat").Equal("fields.color", "gray"), []string{"happycat"}},
+	}
+
+	for _, test := range tests {
+		results, total, err := evaluator.Entries(test.query, entries)
+		assert.Nil(err, test.name)
+		assert.Equal(test.expected, entryIDs(results), test.name)
+		assert.Equal(len(test.expected), total, test.name)
+	}
+}
+
+func TestQueryEvaluatorLocaleFallbacks(t *testing.T) {
+	assert := assert.New(t)
+	entries := queryEntriesFromTestData()
+
+	q := NewQuery().Locale("de-CH").ContentType("cat").Exists("fields.name")
+
+	// de-CH falls back to de-DE, which has no fallback
+	evaluator := QueryEvaluator{Locales: []*Locale{
+		{Code: "en-US", Default: true},
+		{Code: "de-DE"},
+		{Code: "de-CH", FallbackCode: "de-DE"},
+	}}
+	results, _, err := evaluator.Entries(q, entries)
+	assert.Nil(err)
+	assert.Equal([]string{"nyancat"}, entryIDs(results))
+
+	results, _, err = evaluator.Entries(NewQuery().Locale("de-CH").Match("fields.name", "katze"), entries)
+	assert.Nil(err)
+	assert.Equal([]string{"nyancat"}, entryIDs(results))
+
+	// fallback cycles end
+	evaluator.Locales[1].FallbackCode = "de-CH"
+	results, _, err = evaluator.Entries(q, entries)
+	assert.Nil(err)
+	assert.Equal([]string{"nyancat"}, entryIDs(results))
+
+	// without locales the default locale is the fallback
+	results, _, err = QueryEvaluator{}.Entries(q, entries)
+	assert.Nil(err)
+	assert.Equal([]string{"nyancat", "happycat", "garfield"}, entryIDs(results))
+}
+
+func TestQueryEvaluatorOrderAndPages(t *testing.T) {
+	assert := assert.New(t)
+	entries := queryEntriesFromTestData()
+	evaluator := QueryEvaluator{}
+
+	results, total, err := evaluator.Entries(NewQuery().ContentType("cat").Order("fields.lives", false), entries)
+	assert.Nil(err)
+	assert.Equal(3, total)
+	assert.Equal([]string{"happycat", "garfield", "nyancat"}, entryIDs(results))
+
+	// entries without the field are last in both directions
+	results, _, err = evaluator.Entries(NewQuery().Order("fields.lives", true).Order("sys.id", false), entries)
+	assert.Nil(err)
+	assert.Equal([]string{"nyancat", "garfield", "happycat", "jane", "john"}, entryIDs(results))
+
+	results, total, err = evaluator.Entries(NewQuery().Order("sys.createdAt", false).Skip(1).Limit(2), entries)
+	assert.Nil(err)
+	assert.Equal(5, total)
+	assert.Equal([]string{"john", "nyancat"}, entryIDs(results))
+
+	results, _, err = evaluator.Entries(NewQuery().ContentType("cat").Near("fields.location", 48.85, 2.35), entries)
+	assert.Nil(err)
+	assert.Equal([]string{"happycat", "nyancat"}, entryIDs(results))
+
+	results, total, err = evaluator.Entries(NewQuery().ContentType("cat").Limit(0), entries)
+	assert.Nil(err)
+	assert.Equal(3, total)
+	assert.Equal(0, len(results))
+
+	_, _, err = evaluator.Entries(NewQuery().Near("fields.location", 0, 0).Order("sys.id", false), entries)
+	assert.EqualError(err, "[near] can not be combined with order")
+
+	_, _, err = evaluator.Entries(NewQuery().Limit(2000), entries)
+	assert.EqualError(err, "limit value should be between 0 and 1000")
+}
+
+func TestQueryEvaluatorSelect(t *testing.T) {
+	assert := assert.New(t)
+	entries := queryEntriesFromTestData()
+
+	q := NewQuery().ContentType("cat").Select([]string{"fields.name", "fields.color"}).Equal("sys.id", "garfield")
+	results, _, err := QueryEvaluator{}.Entries(q, entries)
+	assert.Nil(err)
+	assert.Equal(1, len(results))
+	assert.Equal("garfield", results[0].Sys.ID)
+	assert.Equal(2, len(results[0].Fields))
+	assert.Contains(results[0].Fields, "name")
+	assert.Contains(results[0].Fields, "color")
+
+	// the given entries are left as is
+	assert.Equal(8, len(entries[2].Fields))
+}
+
+func TestQueryEvaluatorParsedQuery(t *testing.T) {
+	assert := assert.New(t)
+	entries := queryEntriesFromTestData()
+
+	q, err := ParseQuery(map[string][]string{
+		"content_type":        {"cat"},
+		"fields.lives[gte]":   {"9"},
+		"fields.birthday[lt]": {"2000-01-01"},
+	})
+	assert.Nil(err)
+
+	results, _, err := QueryEvaluator{}.Entries(q, entries)
+	assert.Nil(err)
+	assert.Equal([]string{"garfield"}, entryIDs(results))
+}
+
+func TestQueryEvaluatorErrors(t *testing.T) {
+	assert := assert.New(t)
+	entries := queryEntriesFromTestData()
+
+	q := NewQuery().
+		Equal("fields.name", struct{}{}).
+		GreaterThan("fields.lives", struct{}{}).
+		Equal("fields.color", struct{}{})
+
+	// the fields are visited in order, so the error is the same for every run
+	for i := 0; i < 20; i++ {
+		_, _, err := QueryEvaluator{}.Entries(q, entries)
+		assert.EqualError(err, "fields.color: unsupported query value type struct {}")
+	}
+}
+
+func TestQueryEvaluatorAssets(t *testing.T) {
+	assert := assert.New(t)
+
+	assets := []*Asset{
+		{
+			Sys: &Sys{ID: "nyancat-image", Type: "Asset"},
+			Fields: &FileFields{
+				Title: map[string]string{"en-US": "Nyan Cat", "de-DE": "Nyan Katze"},
+				File: map[string]*File{"en-US": {
+					ContentType: "image/png",
+					Detail:      &FileDetail{Size: 12273, Image: &FileImage{Width: 250, Height: 250}},
+				}},
+			},
+		},
+		{
+			Sys: &Sys{ID: "manual", Type: "Asset"},
+			Fields: &FileFields{
+				Title: map[string]string{"en-US": "Cat manual"},
+				File:  map[string]*File{"en-US": {ContentType: "application/pdf", Detail: &FileDetail{Size: 95000}}},
+			},
+		},
+	}
+
+	results, total, err := QueryEvaluator{}.Assets(NewQuery().MimeType(MimeTypeImage), assets)
+	assert.Nil(err)
+	assert.Equal(1, total)
+	assert.Equal("nyancat-image", results[0].Sys.ID)
+
+	results, _, err = QueryEvaluator{}.Assets(NewQuery().GreaterThan("fields.file.details.size", 50000), assets)
+	assert.Nil(err)
+	assert.Equal(1, len(results))
+	assert.Equal("manual", results[0].Sys.ID)
+
+	results, _, err = QueryEvaluator{DefaultLocale: "de-DE"}.Assets(NewQuery().Match("fields.title", "katze"), assets)
+	assert.Nil(err)
+	assert.Equal(1, len(results))
+	assert.Equal("nyancat-image", results[0].Sys.ID)
+
+	// the file of the manual is read in the default locale
+	results, _, err = QueryEvaluator{}.Assets(NewQuery().Locale("de-DE").MimeType(MimeTypePDF), assets)
+	assert.Nil(err)
+	assert.Equal(1, len(results))
+	assert.Equal("manual", results[0].Sys.ID)
+}
@@ -17,7 +17,7 @@ func TestQueryInclude(t *testing.T) {
 
 	assert.Panics(t, func() {
 		q := NewQuery().Include(11)
-		q.String()
+		_ = q.String()
 	}, "out of range `include` should panic")
 }
 
@@ -52,12 +52,12 @@ func TestQuerySelect(t *testing.T) {
 		}
 
 		q := NewQuery().Select(fields)
-		q.String()
+		_ = q.String()
 	}, "select accepts 100 fields max")
 
 	assert.Panics(t, func() {
 		q := NewQuery().Select([]string{"field1", "field2.d1", "field3.d2.d3"})
-		q.String()
+		_ = q.String()
 	}, "select accepts depths 3 max")
 }
 
@@ -71,11 +71,30 @@ func TestQueryEqual(t *testing.T) {
 	expected.Set("field1", "11")
 	assert.Equal(t, expected.Encode(), q.String())
 
-	q = q.Equal("field1", time.Now())
-	expected.Del("field1")
+	now := time.Now()
+	q = q.Equal("field1", now)
+	expected.Set("field1", now.UTC().Format("2006-01-02 15:04:05"))
+	assert.Equal(t, expected.Encode(), q.String())
+
+	q = q.Equal("field1", 1.5)
+	expected.Set("field1", "1.5")
+	assert.Equal(t, expected.Encode(), q.String())
+
+	q = q.Equal("field1", true)
+	expected.Set("field1", "true")
 	assert.Equal(t, expected.Encode(), q.String())
 }
 
+func TestQueryTimeZone(t *testing.T) {
+	berlin := time.FixedZone("CEST", 2*60*60)
+
+	q := NewQuery().GreaterThan("sys.createdAt", time.Date(2020, 6, 1, 1, 30, 0, 0, berlin))
+	assert.Equal(t, "2020-05-31 23:30:00", q.Values().Get("sys.createdAt[gt]"))
+
+	q = NewQuery().Where(FieldPath("date")).In(time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC), time.Date(2020, 6, 1, 12, 0, 0, 0, berlin))
+	assert.Equal(t, "2020-06-01 12:00:00,2020-06-01 10:00:00", q.Values().Get("fields.date[in]"))
+}
+
 func TestQueryNotEqual(t *testing.T) {
 	q := NewQuery().NotEqual("field1", 10)
 	expected := url.Values{}
@@ -87,8 +106,13 @@ func TestQueryNotEqual(t *testing.T) {
 	expected.Set("field1[ne]", "11")
 	assert.Equal(t, expected.Encode(), q.String())
 
-	q = q.NotEqual("field1", time.Now())
-	expected.Del("field1[ne]")
+	now := time.Now()
+	q = q.NotEqual("field1", now)
+	expected.Set("field1[ne]", now.UTC().Format("2006-01-02 15:04:05"))
+	assert.Equal(t, expected.Encode(), q.String())
+
+	q = q.NotEqual("field1", false)
+	expected.Set("field1[ne]", "false")
 	assert.Equal(t, expected.Encode(), q.String())
 }
 
@@ -136,7 +160,7 @@ func TestQueryLessThan(t *testing.T) {
 	now := time.Now()
 	q = NewQuery().LessThan("fields.date", now)
 	expected = url.Values{}
-	expected.Set("fields.date[lt]", now.Format("2006-01-02 15:04:05"))
+	expected.Set("fields.date[lt]", now.UTC().Format("2006-01-02 15:04:05"))
 	assert.Equal(t, expected.Encode(), q.String())
 }
 
@@ -149,7 +173,7 @@ func TestQueryLessThanOrEqual(t *testing.T) {
 	now := time.Now()
 	q = NewQuery().LessThanOrEqual("fields.date", now)
 	expected = url.Values{}
-	expected.Set("fields.date[lte]", now.Format("2006-01-02 15:04:05"))
+	expected.Set("fields.date[lte]", now.UTC().Format("2006-01-02 15:04:05"))
 	assert.Equal(t, expected.Encode(), q.String())
 }
 
@@ -162,7 +186,7 @@ func TestQueryGreaterThan(t *testing.T) {
 	now := time.Now()
 	q = NewQuery().GreaterThan("fields.date", now)
 	expected = url.Values{}
-	expected.Set("fields.date[gt]", now.Format("2006-01-02 15:04:05"))
+	expected.Set("fields.date[gt]", now.UTC().Format("2006-01-02 15:04:05"))
 	assert.Equal(t, expected.Encode(), q.String())
 }
 
@@ -175,7 +199,7 @@ func TestQueryGreaterThanOrEqual(t *testing.T) {
 	now := time.Now()
 	q = NewQuery().GreaterThanOrEqual("fields.date", now)
 	expected = url.Values{}
-	expected.Set("fields.date[gte]", now.Format("2006-01-02 15:04:05"))
+	expected.Set("fields.date[gte]", now.UTC().Format("2006-01-02 15:04:05"))
 	assert.Equal(t, expected.Encode(), q.String())
 }
 
@@ -214,6 +238,118 @@ func TestQueryWithinRadius(t *testing.T) {
 	assert.Equal(t, expected.Encode(), q.String())
 }
 
+func TestQueryGeoCoordinates(t *testing.T) {
+	assert := assert.New(t)
+
+	params, err := NewQuery().Near("fields.center", 52.5208, 13.40953).Build()
+	assert.Nil(err)
+	assert.Equal("52.5208,13.40953", params.Get("fields.center[near]"))
+
+	params, err = NewQuery().Within("fields.center", 40.1, -75.25, 41.3, -73.5).Build()
+	assert.Nil(err)
+	assert.Equal("40.1,-75.25,41.3,-73.5", params.Get("fields.center[within]"))
+
+	params, err = NewQuery().Where(FieldPath("center")).WithinRadius(52.5208, 13.40953, 0.5).Build()
+	assert.Nil(err)
+	assert.Equal("52.5208,13.40953,0.5", params.Get("fields.center[within]"))
+
+	_, err = NewQuery().Near("fields.center", 91, 0).Build()
+	assert.EqualError(err, "fields.center[near]: latitude 91 should be between -90 and 90")
+
+	_, err = NewQuery().Within("fields.center", 0, 0, 10, 181).Build()
+	assert.EqualError(err, "fields.center[within]: longitude 181 should be between -180 and 180")
+
+	_, err = NewQuery().WithinRadius("fields.center", 0, 0, 0).Build()
+	assert.EqualError(err, "fields.center[within]: radius should be positive")
+}
+
+func TestQueryRange(t *testing.T) {
+	from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2020, 12, 31, 23, 59, 59, 0, time.UTC)
+
+	q := NewQuery().Range("fields.date", from, to)
+	expected := url.Values{}
+	expected.Set("fields.date[gte]", "2020-01-01 00:00:00")
+	expected.Set("fields.date[lte]", "2020-12-31 23:59:59")
+	assert.Equal(t, expected.Encode(), q.String())
+
+	q = NewQuery().Where(FieldPath("rating")).Range(1.5, 4)
+	expected = url.Values{}
+	expected.Set("fields.rating[gte]", "1.5")
+	expected.Set("fields.rating[lte]", "4")
+	assert.Equal(t, expected.Encode(), q.String())
+}
+
+func TestQueryMatchArray(t *testing.T) {
+	q := NewQuery().Where(FieldPath("tags")).Match("go")
+	expected := url.Values{}
+	expected.Set("fields.tags[match]", "go")
+	assert.Equal(t, expected.Encode(), q.String())
+}
+
+func TestQueryLinksTo(t *testing.T) {
+	q := NewQuery().LinksToEntry("entry-id")
+	expected := url.Values{}
+	expected.Set("links_to_entry", "entry-id")
+	assert.Equal(t, expected.Encode(), q.String())
+
+	q = NewQuery().LinksToAsset("asset-id")
+	expected = url.Values{}
+	expected.Set("links_to_asset", "asset-id")
+	assert.Equal(t, expected.Encode(), q.String())
+}
+
+func TestQueryReferenceSearch(t *testing.T) {
+	assert := assert.New(t)
+
+	author := FieldPath("author")
+	q := NewQuery().
+		ContentType("post").
+		Where(author).LinkedContentType("person").
+		Where(author.Field("name")).Match("jane")
+
+	expected := url.Values{}
+	expected.Set("content_type", "post")
+	expected.Set("fields.author.sys.contentType.sys.id", "person")
+	expected.Set("fields.author.fields.name[match]", "jane")
+	assert.Equal(expected.Encode(), q.String())
+
+	_, err := NewQuery().Where(author.Field("name")).Equal("jane").Build()
+	assert.EqualError(err, "fields.author.fields.name: reference search requires a content_type parameter")
+
+	_, err = NewQuery().LinkedContentType("fields.author", "person").Build()
+	assert.EqualError(err, "fields.author.sys.contentType.sys.id: reference search requires a content_type parameter")
+}
+
+func TestQuerySysAndMetadata(t *testing.T) {
+	q := NewQuery().
+		Where(QueryFieldSysContentType).Equal("post").
+		Where(QueryFieldSysArchivedAt).NotExists().
+		Where(QueryFieldSysVersion).GreaterThan(3).
+		Where(QueryFieldTags).In("news", "tech")
+
+	expected := url.Values{}
+	expected.Set("sys.contentType.sys.id", "post")
+	expected.Set("sys.archivedAt[exists]", "false")
+	expected.Set("sys.version[gt]", "3")
+	expected.Set("metadata.tags.sys.id[in]", "news,tech")
+	assert.Equal(t, expected.Encode(), q.String())
+}
+
+func TestQueryNotInNumbers(t *testing.T) {
+	q := NewQuery().Where(FieldPath("count")).NotIn(1, 2.5, uint8(3))
+	expected := url.Values{}
+	expected.Set("fields.count[nin]", "1,2.5,3")
+	assert.Equal(t, expected.Encode(), q.String())
+}
+
+func TestQueryAllLocales(t *testing.T) {
+	q := NewQuery().AllLocales()
+	expected := url.Values{}
+	expected.Set("locale", "*")
+	assert.Equal(t, expected.Encode(), q.String())
+}
+
 func TestQueryOrder(t *testing.T) {
 	q := NewQuery().ContentType("ct").Order("field1", false)
 	expected := url.Values{}
@@ -252,7 +388,7 @@ func TestQueryLimit(t *testing.T) {
 
 	assert.Panics(t, func() {
 		q := NewQuery().Limit(3000)
-		q.String()
+		_ = q.String()
 	}, "out of range limit should panic")
 }
 
@@ -287,3 +423,184 @@ func TestQuery(t *testing.T) {
 
 	assert.Equal(t, expected.Encode(), q.String())
 }
+
+func TestQueryTags(t *testing.T) {
+	q := NewQuery().TagsIn("campaign", "legal")
+	expected := url.Values{}
+	expected.Set("metadata.tags.sys.id[in]", "campaign,legal")
+	assert.Equal(t, expected.Encode(), q.String())
+
+	q = NewQuery().TagsAll("campaign", "legal")
+	expected = url.Values{}
+	expected.Set("metadata.tags.sys.id[all]", "campaign,legal")
+	assert.Equal(t, expected.Encode(), q.String())
+
+	q = NewQuery().TagsExist(true)
+	expected = url.Values{}
+	expected.Set("metadata.tags[exists]", "true")
+	assert.Equal(t, expected.Encode(), q.String())
+
+	q = NewQuery().TagsExist(false)
+	expected = url.Values{}
+	expected.Set("metadata.tags[exists]", "false")
+	assert.Equal(t, expected.Encode(), q.String())
+}
+
+func TestQueryBuild(t *testing.T) {
+	assert := assert.New(t)
+
+	params, err := NewQuery().ContentType("ct").Limit(10).Build()
+	assert.Nil(err)
+	assert.Equal("ct", params.Get("content_type"))
+	assert.Equal("10", params.Get("limit"))
+
+	_, err = NewQuery().Include(11).Build()
+	assert.EqualError(err, "include value should be between 0 and 10")
+
+	_, err = NewQuery().Limit(1001).Build()
+	assert.EqualError(err, "limit value should be between 0 and 1000")
+
+	_, err = NewQuery().Select([]string{"field1"}).Build()
+	assert.EqualError(err, "you should provide content_type parameter")
+
+	_, err = NewQuery().Equal("fields.location", struct{}{}).Build()
+	assert.EqualError(err, "fields.location: unsupported query value type struct {}")
+
+	_, err = NewQuery().GreaterThan("fields.count", nil).Build()
+	assert.EqualError(err, "fields.count[gt]: query values can not be nil")
+}
+
+func TestQueryValuesOfValidQueries(t *testing.T) {
+	assert := assert.New(t)
+
+	// Values only panics where Build returns an error
+	for _, q := range []*Query{
+		NewQuery(),
+		NewQuery().Include(10).Limit(1000).Skip(5),
+		NewQuery().ContentType("cat").Select([]string{"fields.name", "sys.id"}),
+		NewQuery().ContentType("cat").Order("fields.lives", true).Equal("fields.color", "black"),
+		NewQuery().Near("fields.center", 52.5, 13.4).GreaterThan("sys.createdAt", time.Now()),
+	} {
+		params, err := q.Build()
+		assert.Nil(err)
+		assert.NotPanics(func() {
+			assert.Equal(params, q.Values())
+			assert.Equal(params.Encode(), q.String())
+		})
+	}
+}
+
+func TestQueryWhere(t *testing.T) {
+	assert := assert.New(t)
+
+	const title QueryField = "fields.title"
+	since := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	q := NewQuery().
+		Where(title).Equal("hello").
+		Where(FieldPath("rating")).GreaterThanOrEqual(4.5).
+		Where(FieldPath("published")).NotEqual(false).
+		Where(QueryFieldSysID).In("a", "b").
+		Where(FieldPath("count")).NotIn(1, 2).
+		Where(FieldPath("tags")).All("x", "y").
+		Where(QueryFieldSysUpdatedAt).LessThan(since).
+		Where(FieldPath("body")).Match("words").
+		Where(FieldPath("image")).Exists().
+		OrderBy(QueryFieldSysCreatedAt, true)
+
+	params, err := q.Build()
+	assert.Nil(err)
+
+	expected := url.Values{}
+	expected.Set("fields.title", "hello")
+	expected.Set("fields.rating[gte]", "4.5")
+	expected.Set("fields.published[ne]", "false")
+	expected.Set("sys.id[in]", "a,b")
+	expected.Set("fields.count[nin]", "1,2")
+	expected.Set("fields.tags[all]", "x,y")
+	expected.Set("sys.updatedAt[lt]", "2020-01-02 03:04:05")
+	expected.Set("fields.body[match]", "words")
+	expected.Set("fields.image[exists]", "true")
+	expected.Set("order", "-sys.createdAt")
+	assert.Equal(expected, params)
+}
+
+func TestParseQuery(t *testing.T) {
+	assert := assert.New(t)
+
+	raw := "content_type=post&fields.author.fields.name%5Bmatch%5D=jane&fields.center%5Bnear%5D=52.5208%2C13.40953" +
+		"&fields.count%5Bnin%5D=1%2C2&fields.date%5Bgte%5D=2020-01-01+00%3A00%3A00&fields.image%5Bexists%5D=false" +
+		"&fields.title=hello&include=2&limit=10&links_to_entry=entry-id&locale=%2A" +
+		"&metadata.tags.sys.id%5Ball%5D=news%2Ctech&order=-sys.createdAt%2Cfields.title" +
+		"&select=fields.title%2Csys.id&skip=20&sys.id%5Bin%5D=a%2Cb&sys.version%5Bne%5D=3"
+
+	values, err := url.ParseQuery(raw)
+	assert.Nil(err)
+
+	q, err := ParseQuery(values)
+	assert.Nil(err)
+	assert.Equal(values, q.Values())
+	assert.Equal(raw, q.String())
+
+	// parsed queries can be modified
+	q.Limit(5).Where(FieldPath("rating")).GreaterThan(3.5)
+	assert.Equal("5", q.Values().Get("limit"))
+	assert.Equal("3.5", q.Values().Get("fields.rating[gt]"))
+
+	// coordinates are written as they were read, unless they are replaced
+	q, err = ParseQuery(url.Values{"fields.center[within]": {"52.50, 13.40, 0.50"}, "fields.location[near]": {"1.50,2"}})
+	assert.Nil(err)
+	assert.Equal("52.50, 13.40, 0.50", q.Values().Get("fields.center[within]"))
+	assert.Equal("1.50,2", q.Values().Get("fields.location[near]"))
+
+	q.WithinRadius("fields.center", 52.50, 13.40, 1.50)
+	assert.Equal("52.5,13.4,1.5", q.Values().Get("fields.center[within]"))
+	assert.Equal("1.50,2", q.Values().Get("fields.location[near]"))
+
+	// zero and empty values are kept
+	raw = "content_type=&include=0&limit=0&links_to_asset=&links_to_entry=&locale=&mimetype_group=&query=&skip=0"
+	values, err = url.ParseQuery(raw)
+	assert.Nil(err)
+
+	q, err = ParseQuery(values)
+	assert.Nil(err)
+	assert.Equal(values, q.Values())
+	assert.Equal(raw, q.String())
+}
+
+func TestParseQueryErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	for values, message := range map[string]string{
+		"limit=many":                      `limit: "many" is not a valid number`,
+		"limit=2000":                      "limit value should be between 0 and 1000",
+		"fields.title%5Bfoo%5D=x":         "fields.title[foo]: unknown operator [foo]",
+		"fields.title%5Bexists%5D=yes":    `fields.title[exists]: expected true or false, got "yes"`,
+		"fields.center%5Bnear%5D=1":       "fields.center[near]: expected latitude and longitude, got 1 numbers",
+		"fields.center%5Bwithin%5D=a%2Cb": `fields.center[within]: "a" is not a valid coordinate`,
+		"select=fields.title":             "you should provide content_type parameter",
+		"sys.id=a&sys.id=b":               "sys.id: expected a single value, got 2",
+	} {
+		parsed, err := url.ParseQuery(values)
+		assert.Nil(err)
+
+		q, err := ParseQuery(parsed)
+		assert.Nil(q, values)
+		assert.EqualError(err, message, values)
+	}
+}
+
+func TestQueryBuildIsDeterministic(t *testing.T) {
+	assert := assert.New(t)
+
+	q := NewQuery()
+	for i := 0; i < 20; i++ {
+		q.Equal("fields.field"+strconv.Itoa(i), struct{}{})
+	}
+
+	// the first invalid field in order is reported on every call
+	for i := 0; i < 10; i++ {
+		_, err := q.Build()
+		assert.EqualError(err, "fields.field0: unsupported query value type struct {}")
+	}
+}
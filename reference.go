@@ -0,0 +1,375 @@
+package contentful
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// EntryReferences model holds an entry and the entities it references
+type EntryReferences struct {
+	Root    *Entry
+	Entries []*Entry
+	Assets  []*Asset
+}
+
+type entryReferencesResponse struct {
+	Items    []*Entry `json:"items"`
+	Includes struct {
+		Entry []*Entry `json:"Entry"`
+		Asset []*Asset `json:"Asset"`
+	} `json:"includes"`
+}
+
+// References returns the entry with every entry and asset it links to, up to `include` levels deep
+func (service *EntriesService) References(spaceID, entryID string, include int) (*EntryReferences, error) {
+	path := fmt.Sprintf("/spaces/%s/environments/%s/entries/%s/references", spaceID, service.c.Environment, entryID)
+	query := url.Values{}
+
+	if include < 0 || include > 10 {
+		return nil, fmt.Errorf("include value should be between 0 and 10")
+	}
+
+	if include > 0 {
+		query.Set("include", strconv.Itoa(include))
+	}
+
+	req, err := service.c.newRequest(http.MethodGet, path, query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var res entryReferencesResponse
+	if err := service.c.do(req, &res); err != nil {
+		return nil, err
+	}
+
+	if len(res.Items) == 0 {
+		return nil, fmt.Errorf("references response does not contain entry %s", entryID)
+	}
+
+	return &EntryReferences{
+		Root:    res.Items[0],
+		Entries: res.Includes.Entry,
+		Assets:  res.Includes.Asset,
+	}, nil
+}
+
+// ReferenceNode model is an entry or asset of a reference graph.
+// Entry and Asset are both nil when the linked entity was not included in the response.
+type ReferenceNode struct {
+	LinkType string
+	ID       string
+	Entry    *Entry
+	Asset    *Asset
+	Links    []*ReferenceNode
+}
+
+// Key returns the unique key of the node in the graph
+func (node *ReferenceNode) Key() string {
+	return node.LinkType + ":" + node.ID
+}
+
+// Resolved reports whether the linked entity is part of the graph
+func (node *ReferenceNode) Resolved() bool {
+	return node.Entry != nil || node.Asset != nil
+}
+
+// ReferenceGraph model
+type ReferenceGraph struct {
+	Root  *ReferenceNode
+	nodes map[string]*ReferenceNode
+}
+
+// ReferenceCycleError is returned when entries reference each other in a loop
+type ReferenceCycleError struct {
+	Path []string
+}
+
+func (e ReferenceCycleError) Error() string {
+	return "reference cycle: " + strings.Join(e.Path, " -> ")
+}
+
+// NewReferenceGraph builds the graph of links between the entities of the references
+func NewReferenceGraph(refs *EntryReferences) *ReferenceGraph {
+	graph := &ReferenceGraph{
+		nodes: map[string]*ReferenceNode{},
+	}
+
+	graph.Root = graph.node("Entry", refs.Root.Sys.ID)
+	graph.Root.Entry = refs.Root
+
+	for _, entry := range refs.Entries {
+		graph.node("Entry", entry.Sys.ID).Entry = entry
+	}
+
+	for _, asset := range refs.Assets {
+		graph.node("Asset", asset.Sys.ID).Asset = asset
+	}
+
+	entries := append([]*Entry{refs.Root}, refs.Entries...)
+	for _, entry := range entries {
+		node := graph.node("Entry", entry.Sys.ID)
+		seen := map[string]bool{}
+
+		for _, link := range entryLinks(entry) {
+			target := graph.node(link.Sys.LinkType, link.Sys.ID)
+			if seen[target.Key()] {
+				continue
+			}
+
+			seen[target.Key()] = true
+			node.Links = append(node.Links, target)
+		}
+	}
+
+	return graph
+}
+
+func (graph *ReferenceGraph) node(linkType, id string) *ReferenceNode {
+	key := linkType + ":" + id
+
+	if node, ok := graph.nodes[key]; ok {
+		return node
+	}
+
+	node := &ReferenceNode{
+		LinkType: linkType,
+		ID:       id,
+	}
+	graph.nodes[key] = node
+
+	return node
+}
+
+// Node returns the node of the given entity, nil if the graph does not contain it
+func (graph *ReferenceGraph) Node(linkType, id string) *ReferenceNode {
+	return graph.nodes[linkType+":"+id]
+}
+
+// Nodes returns every node of the graph ordered by key
+func (graph *ReferenceGraph) Nodes() []*ReferenceNode {
+	keys := make([]string, 0, len(graph.nodes))
+	for key := range graph.nodes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	nodes := make([]*ReferenceNode, 0, len(keys))
+	for _, key := range keys {
+		nodes = append(nodes, graph.nodes[key])
+	}
+
+	return nodes
+}
+
+// Walk visits every node reachable from the root once, depth first.
+// Walking stops at the first error returned by `fn`.
+func (graph *ReferenceGraph) Walk(fn func(node *ReferenceNode, depth int) error) error {
+	visited := map[string]bool{}
+
+	var walk func(node *ReferenceNode, depth int) error
+	walk = func(node *ReferenceNode, depth int) error {
+		if visited[node.Key()] {
+			return nil
+		}
+		visited[node.Key()] = true
+
+		if err := fn(node, depth); err != nil {
+			return err
+		}
+
+		for _, link := range node.Links {
+			if err := walk(link, depth+1); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return walk(graph.Root, 0)
+}
+
+// TopologicalSort orders the nodes so that every node comes after the nodes it links to,
+// which is the order entities have to be published in.
+// A ReferenceCycleError is returned when the graph contains a cycle.
+func (graph *ReferenceGraph) TopologicalSort() ([]*ReferenceNode, error) {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+
+	state := map[string]int{}
+	sorted := []*ReferenceNode{}
+	path := []*ReferenceNode{}
+
+	var visit func(node *ReferenceNode) error
+	visit = func(node *ReferenceNode) error {
+		switch state[node.Key()] {
+		case done:
+			return nil
+		case visiting:
+			cycle := []string{}
+			for i := len(path) - 1; i >= 0; i-- {
+				cycle = append([]string{path[i].Key()}, cycle...)
+				if path[i] == node {
+					break
+				}
+			}
+
+			return ReferenceCycleError{Path: append(cycle, node.Key())}
+		}
+
+		state[node.Key()] = visiting
+		path = append(path, node)
+
+		for _, link := range node.Links {
+			if err := visit(link); err != nil {
+				return err
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[node.Key()] = done
+		sorted = append(sorted, node)
+
+		return nil
+	}
+
+	if err := visit(graph.Root); err != nil {
+		return nil, err
+	}
+
+	for _, node := range graph.Nodes() {
+		if err := visit(node); err != nil {
+			return nil, err
+		}
+	}
+
+	return sorted, nil
+}
+
+// Cycles returns the groups of nodes which reference each other, directly or indirectly
+func (graph *ReferenceGraph) Cycles() [][]*ReferenceNode {
+	index := 0
+	indexes := map[string]int{}
+	lowLinks := map[string]int{}
+	onStack := map[string]bool{}
+	stack := []*ReferenceNode{}
+	cycles := [][]*ReferenceNode{}
+
+	var connect func(node *ReferenceNode)
+	connect = func(node *ReferenceNode) {
+		key := node.Key()
+		indexes[key] = index
+		lowLinks[key] = index
+		index++
+		stack = append(stack, node)
+		onStack[key] = true
+
+		selfLink := false
+		for _, link := range node.Links {
+			if link == node {
+				selfLink = true
+			}
+
+			if _, ok := indexes[link.Key()]; !ok {
+				connect(link)
+				if lowLinks[link.Key()] < lowLinks[key] {
+					lowLinks[key] = lowLinks[link.Key()]
+				}
+			} else if onStack[link.Key()] && indexes[link.Key()] < lowLinks[key] {
+				lowLinks[key] = indexes[link.Key()]
+			}
+		}
+
+		if lowLinks[key] != indexes[key] {
+			return
+		}
+
+		component := []*ReferenceNode{}
+		for {
+			last := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[last.Key()] = false
+			component = append([]*ReferenceNode{last}, component...)
+
+			if last == node {
+				break
+			}
+		}
+
+		if len(component) > 1 || selfLink {
+			cycles = append(cycles, component)
+		}
+	}
+
+	for _, node := range graph.Nodes() {
+		if _, ok := indexes[node.Key()]; !ok {
+			connect(node)
+		}
+	}
+
+	return cycles
+}
+
+// entryLinks returns the entry and asset links found in the fields of the entry
+func entryLinks(entry *Entry) []*Link {
+	links := []*Link{}
+
+	fieldIDs := make([]string, 0, len(entry.Fields))
+	for id := range entry.Fields {
+		fieldIDs = append(fieldIDs, id)
+	}
+	sort.Strings(fieldIDs)
+
+	for _, id := range fieldIDs {
+		value, err := copyFields(map[string]interface{}{"value": entry.Fields[id]})
+		if err != nil {
+			continue
+		}
+
+		links = append(links, findLinks(value["value"])...)
+	}
+
+	return links
+}
+
+func findLinks(value interface{}) []*Link {
+	links := []*Link{}
+
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			links = append(links, findLinks(item)...)
+		}
+	case map[string]interface{}:
+		if sys, ok := v["sys"].(map[string]interface{}); ok && sys["type"] == "Link" {
+			linkType, _ := sys["linkType"].(string)
+			id, _ := sys["id"].(string)
+
+			if (linkType == "Entry" || linkType == "Asset") && id != "" {
+				links = append(links, NewLink(linkType, id))
+			}
+
+			return links
+		}
+
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			links = append(links, findLinks(v[key])...)
+		}
+	}
+
+	return links
+}
@@ -0,0 +1,175 @@
+package contentful
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func referencesFromTestData(t *testing.T) *EntryReferences {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, readTestData("entry_references.json"))
+	})
+
+	// test server
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	refs, err := cma.Entries.References(spaceID, "landing", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return refs
+}
+
+func keys(nodes []*ReferenceNode) []string {
+	res := []string{}
+	for _, node := range nodes {
+		res = append(res, node.Key())
+	}
+
+	return res
+}
+
+func TestEntriesServiceReferences(t *testing.T) {
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("GET", r.Method)
+		assert.Equal("/spaces/"+spaceID+"/environments/master/entries/landing/references", r.URL.Path)
+		assert.Equal("3", r.URL.Query().Get("include"))
+
+		fmt.Fprintln(w, readTestData("entry_references.json"))
+	})
+
+	// test server
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	refs, err := cma.Entries.References(spaceID, "landing", 3)
+	assert.Nil(err)
+	assert.Equal("landing", refs.Root.Sys.ID)
+	assert.Equal(3, len(refs.Entries))
+	assert.Equal("section-a", refs.Entries[1].Sys.ID)
+	assert.Equal(1, len(refs.Assets))
+	assert.Equal("hero-image", refs.Assets[0].Sys.ID)
+
+	_, err = cma.Entries.References(spaceID, "landing", 11)
+	assert.NotNil(err)
+}
+
+func TestReferenceGraphWalk(t *testing.T) {
+	assert := assert.New(t)
+
+	graph := NewReferenceGraph(referencesFromTestData(t))
+	assert.Equal("Entry:landing", graph.Root.Key())
+	assert.Equal(6, len(graph.Nodes()))
+
+	visited := []string{}
+	depths := map[string]int{}
+	err := graph.Walk(func(node *ReferenceNode, depth int) error {
+		visited = append(visited, node.Key())
+		depths[node.Key()] = depth
+		return nil
+	})
+	assert.Nil(err)
+	assert.Equal([]string{
+		"Entry:landing",
+		"Entry:hero",
+		"Asset:hero-image",
+		"Entry:section-a",
+		"Entry:section-b",
+		"Entry:not-included",
+	}, visited)
+	assert.Equal(3, depths["Entry:not-included"])
+
+	assert.True(graph.Node("Asset", "hero-image").Resolved())
+	assert.Equal("Hero image", graph.Node("Asset", "hero-image").Asset.Title("en-US"))
+	assert.False(graph.Node("Entry", "not-included").Resolved())
+	assert.Nil(graph.Node("Entry", "unknown"))
+
+	stop := errors.New("stop")
+	count := 0
+	err = graph.Walk(func(node *ReferenceNode, depth int) error {
+		count++
+		if count == 2 {
+			return stop
+		}
+		return nil
+	})
+	assert.Equal(stop, err)
+	assert.Equal(2, count)
+}
+
+func TestReferenceGraphTopologicalSort(t *testing.T) {
+	assert := assert.New(t)
+
+	graph := NewReferenceGraph(referencesFromTestData(t))
+	sorted, err := graph.TopologicalSort()
+	assert.Nil(err)
+	assert.Equal([]string{
+		"Asset:hero-image",
+		"Entry:hero",
+		"Entry:not-included",
+		"Entry:section-b",
+		"Entry:section-a",
+		"Entry:landing",
+	}, keys(sorted))
+	assert.Equal(0, len(graph.Cycles()))
+}
+
+func TestReferenceGraphCycles(t *testing.T) {
+	assert := assert.New(t)
+
+	link := func(id string) map[string]interface{} {
+		return map[string]interface{}{
+			"en-US": NewLink("Entry", id),
+		}
+	}
+
+	refs := &EntryReferences{
+		Root: &Entry{
+			Sys:    &Sys{ID: "a"},
+			Fields: map[string]interface{}{"next": link("b")},
+		},
+		Entries: []*Entry{
+			{
+				Sys:    &Sys{ID: "b"},
+				Fields: map[string]interface{}{"next": link("c")},
+			},
+			{
+				Sys:    &Sys{ID: "c"},
+				Fields: map[string]interface{}{"next": link("a"), "self": link("c")},
+			},
+		},
+	}
+
+	graph := NewReferenceGraph(refs)
+
+	_, err := graph.TopologicalSort()
+	assert.NotNil(err)
+	cycleErr, ok := err.(ReferenceCycleError)
+	assert.True(ok)
+	assert.Equal([]string{"Entry:a", "Entry:b", "Entry:c", "Entry:a"}, cycleErr.Path)
+	assert.Equal("reference cycle: Entry:a -> Entry:b -> Entry:c -> Entry:a", err.Error())
+
+	cycles := graph.Cycles()
+	assert.Equal(1, len(cycles))
+	cycle := keys(cycles[0])
+	sort.Strings(cycle)
+	assert.Equal([]string{"Entry:a", "Entry:b", "Entry:c"}, cycle)
+}
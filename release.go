@@ -0,0 +1,296 @@
+package contentful
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ReleasesService service
+type ReleasesService service
+
+const (
+	// ReleaseActionPublish publishes every entity of the release
+	ReleaseActionPublish = "publish"
+
+	// ReleaseActionUnpublish unpublishes every entity of the release
+	ReleaseActionUnpublish = "unpublish"
+
+	// ReleaseActionValidate validates every entity of the release
+	ReleaseActionValidate = "validate"
+)
+
+const (
+	// ReleaseActionStatusInProgress release action is still running
+	ReleaseActionStatusInProgress = "inProgress"
+
+	// ReleaseActionStatusSucceeded release action completed successfully
+	ReleaseActionStatusSucceeded = "succeeded"
+
+	// ReleaseActionStatusFailed release action failed
+	ReleaseActionStatusFailed = "failed"
+)
+
+// Release model
+type Release struct {
+	Sys      *Sys             `json:"sys,omitempty"`
+	Title    string           `json:"title"`
+	Entities *ReleaseEntities `json:"entities"`
+}
+
+// ReleaseEntities model
+type ReleaseEntities struct {
+	Sys   *Sys    `json:"sys"`
+	Items []*Link `json:"items"`
+}
+
+// ReleaseAction model
+type ReleaseAction struct {
+	Sys    *Sys           `json:"sys"`
+	Action string         `json:"action,omitempty"`
+	Error  *ErrorResponse `json:"error,omitempty"`
+}
+
+// NewRelease returns a release holding the given entity links
+func NewRelease(title string, entities ...*Link) *Release {
+	release := &Release{
+		Title: title,
+		Entities: &ReleaseEntities{
+			Sys:   &Sys{Type: "Array"},
+			Items: []*Link{},
+		},
+	}
+
+	for _, entity := range entities {
+		release.AddEntity(entity)
+	}
+
+	return release
+}
+
+// AddEntity adds an entry or asset link to the release
+func (release *Release) AddEntity(entity *Link) {
+	if release.Entities == nil {
+		release.Entities = &ReleaseEntities{
+			Sys: &Sys{Type: "Array"},
+		}
+	}
+
+	release.Entities.Items = append(release.Entities.Items, entity)
+}
+
+// GetVersion returns entity version
+func (release *Release) GetVersion() int {
+	version := 1
+	if release.Sys != nil {
+		version = release.Sys.Version
+	}
+
+	return version
+}
+
+// Done reports whether the release action is not running anymore
+func (action *ReleaseAction) Done() bool {
+	return action.Sys != nil && action.Sys.Status != "" && action.Sys.Status != ReleaseActionStatusInProgress
+}
+
+// List returns releases collection
+func (service *ReleasesService) List(spaceID string) *Collection {
+	path := fmt.Sprintf("/spaces/%s/environments/%s/releases", spaceID, service.c.Environment)
+
+	req, err := service.c.newRequest(http.MethodGet, path, nil, nil)
+	if err != nil {
+		return &Collection{}
+	}
+
+	col := NewCollection(&CollectionOptions{})
+	col.c = service.c
+	col.req = req
+
+	return col
+}
+
+// Get returns a single release
+func (service *ReleasesService) Get(spaceID, releaseID string) (*Release, error) {
+	path := fmt.Sprintf("/spaces/%s/environments/%s/releases/%s", spaceID, service.c.Environment, releaseID)
+
+	req, err := service.c.newRequest(http.MethodGet, path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var release Release
+	if err := service.c.do(req, &release); err != nil {
+		return nil, err
+	}
+
+	return &release, nil
+}
+
+// Upsert updates or creates a new release
+func (service *ReleasesService) Upsert(spaceID string, release *Release) error {
+	bytesArray, err := json.Marshal(&struct {
+		Title    string           `json:"title"`
+		Entities *ReleaseEntities `json:"entities"`
+	}{
+		Title:    release.Title,
+		Entities: release.Entities,
+	})
+	if err != nil {
+		return err
+	}
+
+	var path string
+	var method string
+
+	if release.Sys != nil && release.Sys.CreatedAt != "" {
+		path = fmt.Sprintf("/spaces/%s/environments/%s/releases/%s", spaceID, service.c.Environment, release.Sys.ID)
+		method = http.MethodPut
+	} else {
+		path = fmt.Sprintf("/spaces/%s/environments/%s/releases", spaceID, service.c.Environment)
+		method = http.MethodPost
+	}
+
+	req, err := service.c.newRequest(method, path, nil, bytes.NewReader(bytesArray))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("X-Contentful-Version", strconv.Itoa(release.GetVersion()))
+
+	return service.c.do(req, release)
+}
+
+// Delete the release
+func (service *ReleasesService) Delete(spaceID string, release *Release) error {
+	path := fmt.Sprintf("/spaces/%s/environments/%s/releases/%s", spaceID, service.c.Environment, release.Sys.ID)
+
+	req, err := service.c.newRequest(http.MethodDelete, path, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	version := strconv.Itoa(release.Sys.Version)
+	req.Header.Set("X-Contentful-Version", version)
+
+	return service.c.do(req, nil)
+}
+
+// Publish triggers the publish action of the release
+func (service *ReleasesService) Publish(spaceID string, release *Release) (*ReleaseAction, error) {
+	path := fmt.Sprintf("/spaces/%s/environments/%s/releases/%s/published", spaceID, service.c.Environment, release.Sys.ID)
+
+	return service.action(http.MethodPut, path, release, nil)
+}
+
+// Unpublish triggers the unpublish action of the release
+func (service *ReleasesService) Unpublish(spaceID string, release *Release) (*ReleaseAction, error) {
+	path := fmt.Sprintf("/spaces/%s/environments/%s/releases/%s/published", spaceID, service.c.Environment, release.Sys.ID)
+
+	return service.action(http.MethodDelete, path, release, nil)
+}
+
+// Validate triggers the validate action of the release for the publish action
+func (service *ReleasesService) Validate(spaceID string, release *Release) (*ReleaseAction, error) {
+	path := fmt.Sprintf("/spaces/%s/environments/%s/releases/%s/validate", spaceID, service.c.Environment, release.Sys.ID)
+
+	bytesArray, err := json.Marshal(map[string]string{
+		"action": ReleaseActionPublish,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return service.action(http.MethodPost, path, release, bytesArray)
+}
+
+func (service *ReleasesService) action(method, path string, release *Release, body []byte) (*ReleaseAction, error) {
+	req, err := service.c.newRequest(method, path, nil, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	version := strconv.Itoa(release.Sys.Version)
+	req.Header.Set("X-Contentful-Version", version)
+
+	var action ReleaseAction
+	if err := service.c.do(req, &action); err != nil {
+		return nil, err
+	}
+
+	return &action, nil
+}
+
+// ListActions returns the release actions collection of the given release
+func (service *ReleasesService) ListActions(spaceID, releaseID string) *Collection {
+	path := fmt.Sprintf("/spaces/%s/environments/%s/release_actions", spaceID, service.c.Environment)
+
+	req, err := service.c.newRequest(http.MethodGet, path, nil, nil)
+	if err != nil {
+		return &Collection{}
+	}
+
+	col := NewCollection(&CollectionOptions{})
+	col.In("sys.release.sys.id", []string{releaseID})
+	col.c = service.c
+	col.req = req
+
+	return col
+}
+
+// GetAction returns a single release action
+func (service *ReleasesService) GetAction(spaceID, releaseID, actionID string) (*ReleaseAction, error) {
+	path := fmt.Sprintf("/spaces/%s/environments/%s/releases/%s/actions/%s", spaceID, service.c.Environment, releaseID, actionID)
+
+	req, err := service.c.newRequest(http.MethodGet, path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var action ReleaseAction
+	if err := service.c.do(req, &action); err != nil {
+		return nil, err
+	}
+
+	return &action, nil
+}
+
+// WaitForAction polls the release action every `interval` until it is done.
+// The given action is refreshed in place; its error is returned when the action failed.
+func (service *ReleasesService) WaitForAction(ctx context.Context, spaceID string, action *ReleaseAction, interval time.Duration) error {
+	if action.Sys == nil || action.Sys.Release == nil || action.Sys.Release.Sys == nil {
+		return fmt.Errorf("release action is not linked to a release")
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for !action.Done() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		current, err := service.GetAction(spaceID, action.Sys.Release.Sys.ID, action.Sys.ID)
+		if err != nil {
+			return err
+		}
+
+		*action = *current
+	}
+
+	if action.Sys.Status == ReleaseActionStatusFailed {
+		if action.Error != nil {
+			return *action.Error
+		}
+
+		return fmt.Errorf("release action %s failed", action.Sys.ID)
+	}
+
+	return nil
+}
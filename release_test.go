@@ -0,0 +1,252 @@
+package contentful
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReleaseSaveForCreate(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("POST", r.Method)
+		assert.Equal("/spaces/"+spaceID+"/environments/master/releases", r.RequestURI)
+		checkHeaders(r, assert)
+
+		var payload map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&payload)
+		assert.Nil(err)
+		assert.Equal("Spring campaign", payload["title"])
+		assert.Nil(payload["sys"])
+
+		entities := payload["entities"].(map[string]interface{})
+		assert.Equal("Array", entities["sys"].(map[string]interface{})["type"])
+
+		items := entities["items"].([]interface{})
+		assert.Equal(2, len(items))
+		first := items[0].(map[string]interface{})["sys"].(map[string]interface{})
+		assert.Equal("Link", first["type"])
+		assert.Equal("Entry", first["linkType"])
+		assert.Equal("nyancat", first["id"])
+
+		w.WriteHeader(201)
+		fmt.Fprintln(w, readTestData("release.json"))
+	})
+
+	// test server
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	release := NewRelease("Spring campaign", NewLink("Entry", "nyancat"), NewLink("Asset", "happycat"))
+
+	err = cma.Releases.Upsert(spaceID, release)
+	assert.Nil(err)
+	assert.Equal("release-1", release.Sys.ID)
+	assert.Equal(1, release.Sys.Version)
+}
+
+func TestReleaseSaveForUpdate(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("PUT", r.Method)
+		assert.Equal("/spaces/"+spaceID+"/environments/master/releases/release-1", r.RequestURI)
+		assert.Equal("1", r.Header.Get("X-Contentful-Version"))
+
+		var payload map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&payload)
+		assert.Nil(err)
+		items := payload["entities"].(map[string]interface{})["items"].([]interface{})
+		assert.Equal(3, len(items))
+
+		fmt.Fprintln(w, readTestData("release.json"))
+	})
+
+	// test server
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	var release Release
+	err = json.Unmarshal([]byte(readTestData("release.json")), &release)
+	assert.Nil(err)
+
+	release.AddEntity(NewLink("Entry", "happycat"))
+	err = cma.Releases.Upsert(spaceID, &release)
+	assert.Nil(err)
+}
+
+func TestReleasesServiceDelete(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("DELETE", r.Method)
+		assert.Equal("/spaces/"+spaceID+"/environments/master/releases/release-1", r.RequestURI)
+		assert.Equal("1", r.Header.Get("X-Contentful-Version"))
+		w.WriteHeader(204)
+	})
+
+	// test server
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	release := &Release{Sys: &Sys{ID: "release-1", Version: 1}}
+	err = cma.Releases.Delete(spaceID, release)
+	assert.Nil(err)
+}
+
+func TestReleasesServiceActions(t *testing.T) {
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("2", r.Header.Get("X-Contentful-Version"))
+
+		switch r.URL.Path {
+		case "/spaces/" + spaceID + "/environments/master/releases/release-1/published":
+			assert.Contains([]string{"PUT", "DELETE"}, r.Method)
+		case "/spaces/" + spaceID + "/environments/master/releases/release-1/validate":
+			assert.Equal("POST", r.Method)
+
+			var payload map[string]interface{}
+			err := json.NewDecoder(r.Body).Decode(&payload)
+			assert.Nil(err)
+			assert.Equal("publish", payload["action"])
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		w.WriteHeader(202)
+		fmt.Fprintln(w, readTestData("release_action.json"))
+	})
+
+	// test server
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	release := &Release{Sys: &Sys{ID: "release-1", Version: 2}}
+
+	action, err := cma.Releases.Publish(spaceID, release)
+	assert.Nil(err)
+	assert.Equal("action-1", action.Sys.ID)
+	assert.Equal(ReleaseActionPublish, action.Action)
+	assert.Equal("release-1", action.Sys.Release.Sys.ID)
+	assert.False(action.Done())
+
+	_, err = cma.Releases.Unpublish(spaceID, release)
+	assert.Nil(err)
+
+	_, err = cma.Releases.Validate(spaceID, release)
+	assert.Nil(err)
+}
+
+func TestReleasesServiceWaitForAction(t *testing.T) {
+	assert := assert.New(t)
+	requests := 0
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("GET", r.Method)
+		assert.Equal("/spaces/"+spaceID+"/environments/master/releases/release-1/actions/action-1", r.URL.Path)
+
+		requests++
+		if requests < 3 {
+			fmt.Fprintln(w, readTestData("release_action.json"))
+			return
+		}
+
+		fmt.Fprintln(w, readTestData("release_action-succeeded.json"))
+	})
+
+	// test server
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	var action ReleaseAction
+	err := json.Unmarshal([]byte(readTestData("release_action.json")), &action)
+	assert.Nil(err)
+
+	err = cma.Releases.WaitForAction(context.Background(), spaceID, &action, time.Millisecond)
+	assert.Nil(err)
+	assert.Equal(3, requests)
+	assert.Equal(ReleaseActionStatusSucceeded, action.Sys.Status)
+	assert.True(action.Done())
+}
+
+func TestReleasesServiceWaitForFailedAction(t *testing.T) {
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, readTestData("release_action-failed.json"))
+	})
+
+	// test server
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	var action ReleaseAction
+	err := json.Unmarshal([]byte(readTestData("release_action.json")), &action)
+	assert.Nil(err)
+
+	err = cma.Releases.WaitForAction(context.Background(), spaceID, &action, time.Millisecond)
+	assert.NotNil(err)
+	assert.Equal("Entry nyancat is not valid", err.Error())
+	assert.Equal(ReleaseActionStatusFailed, action.Sys.Status)
+}
+
+func TestReleasesServiceWaitForActionCanceled(t *testing.T) {
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, readTestData("release_action.json"))
+	})
+
+	// test server
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	var action ReleaseAction
+	err := json.Unmarshal([]byte(readTestData("release_action.json")), &action)
+	assert.Nil(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err = cma.Releases.WaitForAction(ctx, spaceID, &action, time.Millisecond)
+	assert.Equal(context.DeadlineExceeded, err)
+}
@@ -0,0 +1,318 @@
+package contentful
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Rich text node types
+const (
+	RichTextNodeDocument            = "document"
+	RichTextNodeParagraph           = "paragraph"
+	RichTextNodeHeading1            = "heading-1"
+	RichTextNodeHeading2            = "heading-2"
+	RichTextNodeHeading3            = "heading-3"
+	RichTextNodeHeading4            = "heading-4"
+	RichTextNodeHeading5            = "heading-5"
+	RichTextNodeHeading6            = "heading-6"
+	RichTextNodeOrderedList         = "ordered-list"
+	RichTextNodeUnorderedList       = "unordered-list"
+	RichTextNodeListItem            = "list-item"
+	RichTextNodeBlockquote          = "blockquote"
+	RichTextNodeHR                  = "hr"
+	RichTextNodeTable               = "table"
+	RichTextNodeTableRow            = "table-row"
+	RichTextNodeTableCell           = "table-cell"
+	RichTextNodeTableHeaderCell     = "table-header-cell"
+	RichTextNodeHyperlink           = "hyperlink"
+	RichTextNodeEntryHyperlink      = "entry-hyperlink"
+	RichTextNodeAssetHyperlink      = "asset-hyperlink"
+	RichTextNodeEmbeddedEntryBlock  = "embedded-entry-block"
+	RichTextNodeEmbeddedEntryInline = "embedded-entry-inline"
+	RichTextNodeEmbeddedAssetBlock  = "embedded-asset-block"
+	RichTextNodeText                = "text"
+)
+
+// Rich text mark types
+const (
+	RichTextMarkBold          = "bold"
+	RichTextMarkItalic        = "italic"
+	RichTextMarkUnderline     = "underline"
+	RichTextMarkCode          = "code"
+	RichTextMarkSuperscript   = "superscript"
+	RichTextMarkSubscript     = "subscript"
+	RichTextMarkStrikethrough = "strikethrough"
+)
+
+// RichTextNode model is a node of a rich text document.
+// Text nodes hold a value and marks, every other node holds content nodes.
+type RichTextNode struct {
+	NodeType string
+	Data     *RichTextData
+	Content  []*RichTextNode
+	Value    string
+	Marks    []*RichTextMark
+}
+
+// RichTextData model holds the data of hyperlinks and embedded entries and assets.
+// Properties unknown to the SDK are kept in Extra.
+type RichTextData struct {
+	URI    string
+	Target *Link
+	Extra  map[string]json.RawMessage
+}
+
+// RichTextMark model
+type RichTextMark struct {
+	Type string `json:"type"`
+}
+
+// NewRichTextDocument returns a document holding the given nodes
+func NewRichTextDocument(content ...*RichTextNode) *RichTextNode {
+	return NewRichTextNode(RichTextNodeDocument, content...)
+}
+
+// NewRichTextNode returns a node of the given type holding the given nodes
+func NewRichTextNode(nodeType string, content ...*RichTextNode) *RichTextNode {
+	if content == nil {
+		content = []*RichTextNode{}
+	}
+
+	return &RichTextNode{
+		NodeType: nodeType,
+		Data:     &RichTextData{},
+		Content:  content,
+	}
+}
+
+// NewRichTextText returns a text node with the given marks
+func NewRichTextText(value string, marks ...string) *RichTextNode {
+	node := &RichTextNode{
+		NodeType: RichTextNodeText,
+		Data:     &RichTextData{},
+		Value:    value,
+		Marks:    []*RichTextMark{},
+	}
+
+	for _, mark := range marks {
+		node.Marks = append(node.Marks, &RichTextMark{Type: mark})
+	}
+
+	return node
+}
+
+// NewRichTextHyperlink returns a hyperlink to the given uri
+func NewRichTextHyperlink(uri string, content ...*RichTextNode) *RichTextNode {
+	node := NewRichTextNode(RichTextNodeHyperlink, content...)
+	node.Data.URI = uri
+
+	return node
+}
+
+// NewRichTextEmbed returns an embedded entry or asset, or an entry or asset hyperlink, to the given target
+func NewRichTextEmbed(nodeType string, target *Link, content ...*RichTextNode) *RichTextNode {
+	node := NewRichTextNode(nodeType, content...)
+	node.Data.Target = target
+
+	return node
+}
+
+// ParseRichText decodes a rich text document
+func ParseRichText(data []byte) (*RichTextNode, error) {
+	var node RichTextNode
+	if err := json.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+
+	if node.NodeType != RichTextNodeDocument {
+		return nil, fmt.Errorf("rich text root must be a document, got %q", node.NodeType)
+	}
+
+	return &node, nil
+}
+
+// RichTextDocument returns the typed document of a RichText field
+func (ef *EntryField) RichTextDocument(locale string) (*RichTextNode, error) {
+	// documents set by hand are used as they are
+	if val, err := ef.typedValue(locale, FieldTypeRichText); err == nil {
+		if node, ok := val.(*RichTextNode); ok {
+			if node.NodeType != RichTextNodeDocument {
+				return nil, ef.mismatch(FieldTypeRichText, val)
+			}
+
+			return node, nil
+		}
+	}
+
+	val, err := ef.RichTextValue(locale)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(val)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseRichText(data)
+}
+
+// IsText reports whether the node is a text node
+func (node *RichTextNode) IsText() bool {
+	return node.NodeType == RichTextNodeText
+}
+
+// HasMark reports whether the text node carries the given mark
+func (node *RichTextNode) HasMark(mark string) bool {
+	for _, m := range node.Marks {
+		if m.Type == mark {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Walk calls fn for the node and each of its descendants, depth first.
+// Returning false from fn skips the descendants of the node.
+func (node *RichTextNode) Walk(fn func(node *RichTextNode) bool) {
+	if !fn(node) {
+		return
+	}
+
+	for _, child := range node.Content {
+		child.Walk(fn)
+	}
+}
+
+// PlainText returns the text of the node without any formatting, blocks are separated by a new line
+func (node *RichTextNode) PlainText() string {
+	if node.IsText() {
+		return node.Value
+	}
+
+	parts := []string{}
+	for _, child := range node.Content {
+		parts = append(parts, child.PlainText())
+	}
+
+	separator := ""
+	if node.NodeType == RichTextNodeDocument || node.NodeType == RichTextNodeOrderedList ||
+		node.NodeType == RichTextNodeUnorderedList || node.NodeType == RichTextNodeListItem {
+		separator = "\n"
+	}
+
+	return strings.Join(parts, separator)
+}
+
+// MarshalJSON for custom json marshaling
+func (node *RichTextNode) MarshalJSON() ([]byte, error) {
+	data := node.Data
+	if data == nil {
+		data = &RichTextData{}
+	}
+
+	if node.IsText() {
+		marks := node.Marks
+		if marks == nil {
+			marks = []*RichTextMark{}
+		}
+
+		return json.Marshal(&struct {
+			NodeType string          `json:"nodeType"`
+			Value    string          `json:"value"`
+			Marks    []*RichTextMark `json:"marks"`
+			Data     *RichTextData   `json:"data"`
+		}{node.NodeType, node.Value, marks, data})
+	}
+
+	content := node.Content
+	if content == nil {
+		content = []*RichTextNode{}
+	}
+
+	return json.Marshal(&struct {
+		NodeType string          `json:"nodeType"`
+		Data     *RichTextData   `json:"data"`
+		Content  []*RichTextNode `json:"content"`
+	}{node.NodeType, data, content})
+}
+
+// UnmarshalJSON for custom json unmarshaling
+func (node *RichTextNode) UnmarshalJSON(data []byte) error {
+	var payload struct {
+		NodeType string          `json:"nodeType"`
+		Data     *RichTextData   `json:"data"`
+		Content  []*RichTextNode `json:"content"`
+		Value    string          `json:"value"`
+		Marks    []*RichTextMark `json:"marks"`
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	if payload.NodeType == "" {
+		return fmt.Errorf("rich text node without a node type")
+	}
+
+	if payload.Data == nil {
+		payload.Data = &RichTextData{}
+	}
+
+	node.NodeType = payload.NodeType
+	node.Data = payload.Data
+	node.Content = payload.Content
+	node.Value = payload.Value
+	node.Marks = payload.Marks
+
+	return nil
+}
+
+// MarshalJSON for custom json marshaling
+func (data *RichTextData) MarshalJSON() ([]byte, error) {
+	payload := map[string]interface{}{}
+
+	for key, value := range data.Extra {
+		payload[key] = value
+	}
+
+	if data.URI != "" {
+		payload["uri"] = data.URI
+	}
+
+	if data.Target != nil {
+		payload["target"] = data.Target
+	}
+
+	return json.Marshal(payload)
+}
+
+// UnmarshalJSON for custom json unmarshaling
+func (data *RichTextData) UnmarshalJSON(b []byte) error {
+	payload := map[string]json.RawMessage{}
+	if err := json.Unmarshal(b, &payload); err != nil {
+		return err
+	}
+
+	if raw, ok := payload["uri"]; ok {
+		if err := json.Unmarshal(raw, &data.URI); err != nil {
+			return err
+		}
+		delete(payload, "uri")
+	}
+
+	if raw, ok := payload["target"]; ok {
+		if err := json.Unmarshal(raw, &data.Target); err != nil {
+			return err
+		}
+		delete(payload, "target")
+	}
+
+	if len(payload) > 0 {
+		data.Extra = payload
+	}
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package contentful
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+)
+
+// RichTextNodeRenderer renders a rich text node to html, `children` holds the
+// rendered content of the node
+type RichTextNodeRenderer func(node *RichTextNode, children string) (string, error)
+
+// RichTextMarkRenderer wraps rendered text with the html of a mark
+type RichTextMarkRenderer func(text string) string
+
+// RichTextHTMLRenderer renders rich text documents to html.
+// Node and mark renderers are looked up by type and can be replaced or added,
+// nodes without a renderer render their children only.
+type RichTextHTMLRenderer struct {
+	NodeRenderers map[string]RichTextNodeRenderer
+	MarkRenderers map[string]RichTextMarkRenderer
+}
+
+func wrapHTML(tag string) RichTextNodeRenderer {
+	return func(node *RichTextNode, children string) (string, error) {
+		return fmt.Sprintf("<%s>%s</%s>", tag, children, tag), nil
+	}
+}
+
+func wrapMark(tag string) RichTextMarkRenderer {
+	return func(text string) string {
+		return fmt.Sprintf("<%s>%s</%s>", tag, text, tag)
+	}
+}
+
+// renderEmbed is the default of embedded entries and assets, which can only be
+// rendered meaningfully by the application
+func renderEmbed(tag string) RichTextNodeRenderer {
+	return func(node *RichTextNode, children string) (string, error) {
+		id := ""
+		if node.Data != nil && node.Data.Target != nil && node.Data.Target.Sys != nil {
+			id = node.Data.Target.Sys.ID
+		}
+
+		return fmt.Sprintf("<%s>type: %s id: %s</%s>", tag, node.NodeType, html.EscapeString(id), tag), nil
+	}
+}
+
+// hyperlinkSchemes are the schemes of hyperlinks rendered by default, other
+// schemes like javascript: can run code in the browser
+var hyperlinkSchemes = map[string]bool{"http": true, "https": true, "mailto": true}
+
+// safeHyperlinkURI reports whether the uri is a relative link or uses one of the hyperlinkSchemes
+func safeHyperlinkURI(uri string) bool {
+	// browsers ignore surrounding whitespace and control characters, url.Parse
+	// rejects them in the middle of the uri
+	uri = strings.TrimFunc(uri, func(r rune) bool {
+		return r <= ' '
+	})
+
+	u, err := url.Parse(uri)
+	if err != nil {
+		return false
+	}
+
+	return u.Scheme == "" || hyperlinkSchemes[strings.ToLower(u.Scheme)]
+}
+
+// NewRichTextHTMLRenderer returns a renderer with the default renderers of every node and mark type
+func NewRichTextHTMLRenderer() *RichTextHTMLRenderer {
+	return &RichTextHTMLRenderer{
+		NodeRenderers: map[string]RichTextNodeRenderer{
+			RichTextNodeDocument: func(node *RichTextNode, children string) (string, error) {
+				return children, nil
+			},
+			RichTextNodeParagraph:       wrapHTML("p"),
+			RichTextNodeHeading1:        wrapHTML("h1"),
+			RichTextNodeHeading2:        wrapHTML("h2"),
+			RichTextNodeHeading3:        wrapHTML("h3"),
+			RichTextNodeHeading4:        wrapHTML("h4"),
+			RichTextNodeHeading5:        wrapHTML("h5"),
+			RichTextNodeHeading6:        wrapHTML("h6"),
+			RichTextNodeOrderedList:     wrapHTML("ol"),
+			RichTextNodeUnorderedList:   wrapHTML("ul"),
+			RichTextNodeListItem:        wrapHTML("li"),
+			RichTextNodeBlockquote:      wrapHTML("blockquote"),
+			RichTextNodeTable:           wrapHTML("table"),
+			RichTextNodeTableRow:        wrapHTML("tr"),
+			RichTextNodeTableCell:       wrapHTML("td"),
+			RichTextNodeTableHeaderCell: wrapHTML("th"),
+			RichTextNodeHR: func(node *RichTextNode, children string) (string, error) {
+				return "<hr/>", nil
+			},
+			RichTextNodeHyperlink: func(node *RichTextNode, children string) (string, error) {
+				uri := ""
+				if node.Data != nil {
+					uri = node.Data.URI
+				}
+
+				// links with an unsafe scheme render their text only
+				if !safeHyperlinkURI(uri) {
+					return children, nil
+				}
+
+				return fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(uri), children), nil
+			},
+			RichTextNodeEntryHyperlink:      renderEmbed("span"),
+			RichTextNodeAssetHyperlink:      renderEmbed("span"),
+			RichTextNodeEmbeddedEntryInline: renderEmbed("span"),
+			RichTextNodeEmbeddedEntryBlock:  renderEmbed("div"),
+			RichTextNodeEmbeddedAssetBlock:  renderEmbed("div"),
+		},
+		MarkRenderers: map[string]RichTextMarkRenderer{
+			RichTextMarkBold:          wrapMark("b"),
+			RichTextMarkItalic:        wrapMark("i"),
+			RichTextMarkUnderline:     wrapMark("u"),
+			RichTextMarkCode:          wrapMark("code"),
+			RichTextMarkSuperscript:   wrapMark("sup"),
+			RichTextMarkSubscript:     wrapMark("sub"),
+			RichTextMarkStrikethrough: wrapMark("s"),
+		},
+	}
+}
+
+// Render returns the html of the node and its descendants
+func (r *RichTextHTMLRenderer) Render(node *RichTextNode) (string, error) {
+	if node.IsText() {
+		text := html.EscapeString(node.Value)
+		for _, mark := range node.Marks {
+			if render, ok := r.MarkRenderers[mark.Type]; ok {
+				text = render(text)
+			}
+		}
+
+		return text, nil
+	}
+
+	var children strings.Builder
+	for _, child := range node.Content {
+		rendered, err := r.Render(child)
+		if err != nil {
+			return "", err
+		}
+
+		children.WriteString(rendered)
+	}
+
+	render, ok := r.NodeRenderers[node.NodeType]
+	if !ok {
+		return children.String(), nil
+	}
+
+	return render(node, children.String())
+}
@@ -0,0 +1,1334 @@
+package contentful
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// RichTextConversionIssue describes a construct which could not be converted losslessly
+type RichTextConversionIssue struct {
+	// Line of the markdown source, 0 when converting from rich text
+	Line      int
+	Construct string
+	Message   string
+}
+
+// RichTextConversionReport lists the issues found while converting rich text from or to markdown
+type RichTextConversionReport struct {
+	Issues []*RichTextConversionIssue
+}
+
+func (report *RichTextConversionReport) add(line int, construct, format string, args ...interface{}) {
+	report.Issues = append(report.Issues, &RichTextConversionIssue{
+		Line:      line,
+		Construct: construct,
+		Message:   fmt.Sprintf(format, args...),
+	})
+}
+
+// Lossless reports whether the conversion did not drop or alter anything
+func (report *RichTextConversionReport) Lossless() bool {
+	return len(report.Issues) == 0
+}
+
+// mdEmbeddedInline starts an inline entry embed, closed by `}}` after the id
+const mdEmbeddedInline = "{{embedded-entry-inline:"
+
+var (
+	mdATXHeading      = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	mdThematicBreak   = regexp.MustCompile(`^ {0,3}(?:(?:\*[ \t]*){3,}|(?:-[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	mdFence           = regexp.MustCompile("^( {0,3})(`{3,}|~{3,})(.*)$")
+	mdListItem        = regexp.MustCompile(`^( {0,3})([-+*]|\d{1,9}[.)])(?:([ \t]+)(.*))?$`)
+	mdBlockquote      = regexp.MustCompile(`^ {0,3}> ?(.*)$`)
+	mdSetextH1        = regexp.MustCompile(`^ {0,3}=+[ \t]*$`)
+	mdSetextH2        = regexp.MustCompile(`^ {0,3}-+[ \t]*$`)
+	mdTableDelimiter  = regexp.MustCompile(`^[ \t]*\|?[ \t]*:?-+:?[ \t]*(?:\|[ \t]*:?-+:?[ \t]*)*\|?[ \t]*$`)
+	mdEmbeddedBlock   = regexp.MustCompile(`^ {0,3}\{\{(embedded-entry-block|embedded-asset-block):([^}\s]+)\}\}[ \t]*$`)
+	mdHyperlinkTarget = regexp.MustCompile(`^\{\{(entry-hyperlink|asset-hyperlink):([^}\s]+)\}\}$`)
+	mdAutolink        = regexp.MustCompile(`^<([a-zA-Z][a-zA-Z0-9+.-]{1,31}:[^<>\s]*)>`)
+	mdEmailAutolink   = regexp.MustCompile(`^<([^<>\s@]+@[^<>\s]+)>`)
+	mdHTMLTag         = regexp.MustCompile(`^</?[a-zA-Z][a-zA-Z0-9-]*(?:\s[^>]*)?/?>`)
+	mdOrderedStart    = regexp.MustCompile(`^(\d+)([.)])`)
+)
+
+type markdownParser struct {
+	report *RichTextConversionReport
+}
+
+// MarkdownToRichText converts CommonMark, with GitHub tables and strikethrough, to a rich text document.
+// Embedded entries and assets are written as `{{embedded-entry-block:<id>}}`,
+// `{{embedded-asset-block:<id>}}` and `{{embedded-entry-inline:<id>}}`, links to entries
+// and assets as `[text]({{entry-hyperlink:<id>}})` and `[text]({{asset-hyperlink:<id>}})`.
+func MarkdownToRichText(markdown string) (*RichTextNode, *RichTextConversionReport) {
+	p := &markdownParser{report: &RichTextConversionReport{}}
+
+	markdown = strings.Replace(markdown, "\r\n", "\n", -1)
+	lines := strings.Split(markdown, "\n")
+	for i, line := range lines {
+		lines[i] = expandTabs(line)
+	}
+
+	return NewRichTextDocument(p.blocks(lines, 1)...), p.report
+}
+
+func expandTabs(line string) string {
+	var b strings.Builder
+	for i, c := range line {
+		if c != '\t' {
+			b.WriteString(line[i:])
+			break
+		}
+		b.WriteString("    ")
+	}
+
+	return b.String()
+}
+
+func isBlank(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+func indentOf(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// interrupts reports whether the line starts a block which ends a paragraph
+func interrupts(line string) bool {
+	if isBlank(line) || mdATXHeading.MatchString(line) || mdThematicBreak.MatchString(line) ||
+		mdFence.MatchString(line) || mdBlockquote.MatchString(line) || mdEmbeddedBlock.MatchString(line) {
+		return true
+	}
+
+	// only non empty lists starting at one interrupt a paragraph
+	if m := mdListItem.FindStringSubmatch(line); m != nil && strings.TrimSpace(m[4]) != "" {
+		if om := mdOrderedStart.FindStringSubmatch(m[2]); om != nil {
+			return om[1] == "1"
+		}
+
+		return true
+	}
+
+	return false
+}
+
+func (p *markdownParser) blocks(lines []string, lineNo int) []*RichTextNode {
+	nodes := []*RichTextNode{}
+
+	for i := 0; i < len(lines); {
+		line := lines[i]
+
+		switch {
+		case isBlank(line):
+			i++
+
+		case mdFence.MatchString(line):
+			var node *RichTextNode
+			node, i = p.fencedCode(lines, i, lineNo)
+			nodes = append(nodes, node)
+
+		case indentOf(line) >= 4:
+			code := []string{}
+			for i < len(lines) && (isBlank(lines[i]) || indentOf(lines[i]) >= 4) {
+				if len(lines[i]) >= 4 {
+					code = append(code, lines[i][4:])
+				} else {
+					code = append(code, "")
+				}
+				i++
+			}
+
+			for len(code) > 0 && isBlank(code[len(code)-1]) {
+				code = code[:len(code)-1]
+			}
+
+			nodes = append(nodes, codeParagraph(strings.Join(code, "\n")))
+
+		case mdEmbeddedBlock.MatchString(line):
+			m := mdEmbeddedBlock.FindStringSubmatch(line)
+			linkType := "Entry"
+			if m[1] == RichTextNodeEmbeddedAssetBlock {
+				linkType = "Asset"
+			}
+
+			nodes = append(nodes, NewRichTextEmbed(m[1], NewLink(linkType, m[2])))
+			i++
+
+		case mdATXHeading.MatchString(line):
+			m := mdATXHeading.FindStringSubmatch(line)
+			nodes = append(nodes, p.inlineBlock(headingType(len(m[1])), m[2], lineNo+i))
+			i++
+
+		case mdThematicBreak.MatchString(line):
+			nodes = append(nodes, NewRichTextNode(RichTextNodeHR))
+			i++
+
+		case mdBlockquote.MatchString(line):
+			start := i
+			quoted := []string{}
+			for i < len(lines) {
+				if m := mdBlockquote.FindStringSubmatch(lines[i]); m != nil {
+					quoted = append(quoted, m[1])
+				} else if !isBlank(lines[i]) && len(quoted) > 0 && !isBlank(quoted[len(quoted)-1]) && !interrupts(lines[i]) {
+					// lazy continuation of a quoted paragraph
+					quoted = append(quoted, lines[i])
+				} else {
+					break
+				}
+				i++
+			}
+
+			content := p.blocks(quoted, lineNo+start)
+			for _, child := range content {
+				if child.NodeType != RichTextNodeParagraph {
+					p.report.add(lineNo+start, RichTextNodeBlockquote, "quotes can only hold paragraphs, found %s", child.NodeType)
+					break
+				}
+			}
+
+			nodes = append(nodes, NewRichTextNode(RichTextNodeBlockquote, content...))
+
+		case mdListItem.MatchString(line):
+			var node *RichTextNode
+			node, i = p.list(lines, i, lineNo)
+			nodes = append(nodes, node)
+
+		case i+1 < len(lines) && p.isTableStart(lines[i], lines[i+1]):
+			var node *RichTextNode
+			node, i = p.table(lines, i, lineNo)
+			nodes = append(nodes, node)
+
+		default:
+			var node *RichTextNode
+			node, i = p.paragraph(lines, i, lineNo)
+			nodes = append(nodes, node)
+		}
+	}
+
+	return nodes
+}
+
+func headingType(level int) string {
+	return "heading-" + strconv.Itoa(level)
+}
+
+func codeParagraph(code string) *RichTextNode {
+	return NewRichTextNode(RichTextNodeParagraph, NewRichTextText(code, RichTextMarkCode))
+}
+
+func (p *markdownParser) inlineBlock(nodeType, text string, lineNo int) *RichTextNode {
+	content := p.inline(text, []string{}, lineNo)
+	if len(content) == 0 {
+		content = append(content, NewRichTextText(""))
+	}
+
+	return NewRichTextNode(nodeType, content...)
+}
+
+func (p *markdownParser) paragraph(lines []string, i, lineNo int) (*RichTextNode, int) {
+	start := i
+	text := []string{strings.TrimSpace(lines[i])}
+	i++
+
+	for i < len(lines) {
+		line := lines[i]
+
+		if mdSetextH1.MatchString(line) {
+			return p.inlineBlock(RichTextNodeHeading1, strings.Join(text, "\n"), lineNo+start), i + 1
+		}
+
+		if mdSetextH2.MatchString(line) {
+			return p.inlineBlock(RichTextNodeHeading2, strings.Join(text, "\n"), lineNo+start), i + 1
+		}
+
+		if interrupts(line) {
+			break
+		}
+
+		text = append(text, strings.TrimLeft(line, " "))
+		i++
+	}
+
+	return p.inlineBlock(RichTextNodeParagraph, strings.Join(text, "\n"), lineNo+start), i
+}
+
+func (p *markdownParser) fencedCode(lines []string, i, lineNo int) (*RichTextNode, int) {
+	m := mdFence.FindStringSubmatch(lines[i])
+	indent, fence, info := len(m[1]), m[2], strings.TrimSpace(m[3])
+
+	if info != "" {
+		p.report.add(lineNo+i, "code", "the language %q of the code block is dropped", info)
+	}
+
+	code := []string{}
+	for i++; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		if indentOf(line) < 4 && strings.HasPrefix(trimmed, fence[:1]) &&
+			len(trimmed) >= len(fence) && strings.Trim(trimmed, fence[:1]) == "" {
+			i++
+			break
+		}
+
+		strip := indentOf(line)
+		if strip > indent {
+			strip = indent
+		}
+		code = append(code, line[strip:])
+	}
+
+	return codeParagraph(strings.Join(code, "\n")), i
+}
+
+func (p *markdownParser) list(lines []string, i, lineNo int) (*RichTextNode, int) {
+	first := mdListItem.FindStringSubmatch(lines[i])
+	ordered := mdOrderedStart.MatchString(first[2])
+	delimiter := first[2][len(first[2])-1:]
+
+	nodeType := RichTextNodeUnorderedList
+	if ordered {
+		nodeType = RichTextNodeOrderedList
+		if om := mdOrderedStart.FindStringSubmatch(first[2]); om[1] != "1" {
+			p.report.add(lineNo+i, nodeType, "lists always start at 1, the start number %s is dropped", om[1])
+		}
+	}
+
+	items := []*RichTextNode{}
+	for i < len(lines) {
+		if mdThematicBreak.MatchString(lines[i]) {
+			break
+		}
+
+		m := mdListItem.FindStringSubmatch(lines[i])
+		if m == nil || mdOrderedStart.MatchString(m[2]) != ordered || m[2][len(m[2])-1:] != delimiter {
+			break
+		}
+
+		indent := len(m[1]) + len(m[2]) + 1
+		firstLine := ""
+		if strings.TrimSpace(m[4]) != "" {
+			if spaces := len(m[3]); spaces <= 4 {
+				indent = len(m[1]) + len(m[2]) + spaces
+				firstLine = m[4]
+			} else {
+				firstLine = strings.Repeat(" ", spaces-1) + m[4]
+			}
+		}
+
+		start := i
+		itemLines := []string{firstLine}
+		for i++; i < len(lines); i++ {
+			line := lines[i]
+
+			if isBlank(line) {
+				next := i
+				for next < len(lines) && isBlank(lines[next]) {
+					next++
+				}
+
+				if next < len(lines) && indentOf(lines[next]) >= indent {
+					itemLines = append(itemLines, "")
+					continue
+				}
+
+				break
+			}
+
+			if indentOf(line) >= indent {
+				itemLines = append(itemLines, line[indent:])
+				continue
+			}
+
+			// lazy continuation of the last paragraph of the item
+			if !isBlank(itemLines[len(itemLines)-1]) && !interrupts(line) && !mdListItem.MatchString(line) {
+				itemLines = append(itemLines, strings.TrimLeft(line, " "))
+				continue
+			}
+
+			break
+		}
+
+		content := p.blocks(itemLines, lineNo+start)
+		if len(content) == 0 {
+			content = append(content, NewRichTextNode(RichTextNodeParagraph, NewRichTextText("")))
+		}
+		items = append(items, NewRichTextNode(RichTextNodeListItem, content...))
+
+		next := i
+		for next < len(lines) && isBlank(lines[next]) {
+			next++
+		}
+
+		if next < len(lines) && next > i && mdListItem.MatchString(lines[next]) && !mdThematicBreak.MatchString(lines[next]) {
+			i = next
+		}
+	}
+
+	return NewRichTextNode(nodeType, items...), i
+}
+
+func splitTableRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+
+	cells := []string{}
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		if line[i] == '\\' && i+1 < len(line) && line[i+1] == '|' {
+			cell.WriteString(`\|`)
+			i++
+			continue
+		}
+
+		if line[i] == '|' {
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+			continue
+		}
+
+		cell.WriteByte(line[i])
+	}
+
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+func (p *markdownParser) isTableStart(header, delimiter string) bool {
+	if !strings.Contains(header, "|") || !mdTableDelimiter.MatchString(delimiter) {
+		return false
+	}
+
+	return len(splitTableRow(header)) == len(splitTableRow(delimiter))
+}
+
+func (p *markdownParser) table(lines []string, i, lineNo int) (*RichTextNode, int) {
+	header := splitTableRow(lines[i])
+
+	for _, cell := range splitTableRow(lines[i+1]) {
+		if strings.Contains(cell, ":") {
+			p.report.add(lineNo+i+1, RichTextNodeTable, "column alignments are dropped")
+			break
+		}
+	}
+
+	row := func(cells []string, cellType string, line int) *RichTextNode {
+		if len(cells) > len(header) {
+			p.report.add(line, RichTextNodeTableRow, "cells beyond the %d columns of the header are dropped", len(header))
+			cells = cells[:len(header)]
+		}
+
+		nodes := []*RichTextNode{}
+		for c := range header {
+			text := ""
+			if c < len(cells) {
+				text = cells[c]
+			}
+
+			nodes = append(nodes, NewRichTextNode(cellType, p.inlineBlock(RichTextNodeParagraph, text, line)))
+		}
+
+		return NewRichTextNode(RichTextNodeTableRow, nodes...)
+	}
+
+	rows := []*RichTextNode{row(header, RichTextNodeTableHeaderCell, lineNo+i)}
+	for i += 2; i < len(lines); i++ {
+		if isBlank(lines[i]) || !strings.Contains(lines[i], "|") || interrupts(lines[i]) {
+			break
+		}
+
+		rows = append(rows, row(splitTableRow(lines[i]), RichTextNodeTableCell, lineNo+i))
+	}
+
+	return NewRichTextNode(RichTextNodeTable, rows...), i
+}
+
+func isASCIIPunct(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
+
+func isAlnum(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\t'
+}
+
+func runLength(s string, i int, c byte) int {
+	n := 0
+	for i+n < len(s) && s[i+n] == c {
+		n++
+	}
+
+	return n
+}
+
+func withMark(marks []string, mark string) []string {
+	res := append([]string{}, marks...)
+	for _, m := range marks {
+		if m == mark {
+			return res
+		}
+	}
+
+	return append(res, mark)
+}
+
+func sameMarks(node *RichTextNode, marks []string) bool {
+	if len(node.Marks) != len(marks) {
+		return false
+	}
+
+	for _, mark := range marks {
+		if !node.HasMark(mark) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func appendText(nodes []*RichTextNode, value string, marks []string) []*RichTextNode {
+	if value == "" {
+		return nodes
+	}
+
+	if len(nodes) > 0 {
+		last := nodes[len(nodes)-1]
+		if last.IsText() && sameMarks(last, marks) {
+			last.Value += value
+			return nodes
+		}
+	}
+
+	return append(nodes, NewRichTextText(value, marks...))
+}
+
+// closingBacktick returns the start of the next run of exactly n backticks
+func closingBacktick(s string, from, n int) int {
+	for j := from; j < len(s); {
+		if s[j] != '`' {
+			j++
+			continue
+		}
+
+		run := runLength(s, j, '`')
+		if run == n {
+			return j
+		}
+		j += run
+	}
+
+	return -1
+}
+
+// closingDelimiter returns the start of the delimiter closing an emphasis opened before `from`
+func closingDelimiter(s string, from int, delim string) int {
+	c := delim[0]
+
+	for j := from; j < len(s); {
+		switch {
+		case s[j] == '\\':
+			j += 2
+			continue
+		case s[j] == '`':
+			n := runLength(s, j, '`')
+			if end := closingBacktick(s, j+n, n); end >= 0 {
+				j = end + n
+				continue
+			}
+			j += n
+			continue
+		case s[j] != c:
+			j++
+			continue
+		}
+
+		run := runLength(s, j, c)
+		end := j + run
+
+		// a double delimiter inside a single one opens a nested emphasis
+		if run < len(delim) || (len(delim) == 1 && run == 2) {
+			j = end
+			continue
+		}
+
+		candidate := end - len(delim)
+		if candidate > from && !isSpace(s[candidate-1]) && (c != '_' || end == len(s) || !isAlnum(s[end])) {
+			return candidate
+		}
+
+		j = end
+	}
+
+	return -1
+}
+
+// inlineIndex looks up the ends of emphasis and links in the text of an inline run.
+// The lookups are shared by all the openers of the text, so unclosed openers are not
+// scanned to the end of the text again and again.
+type inlineIndex struct {
+	s string
+
+	// brackets holds the closing bracket of every opening bracket, -1 if unclosed
+	brackets map[int]int
+	// destEnds holds the end of a link destination starting at each position
+	destEnds []int
+	// bytes holds the position and the result of the last lookup of a byte
+	bytes map[byte][2]int
+	// noCloser holds the position after which a delimiter has no closer
+	noCloser map[string]int
+}
+
+func newInlineIndex(s string) *inlineIndex {
+	return &inlineIndex{s: s, bytes: map[byte][2]int{}, noCloser: map[string]int{}}
+}
+
+// matchBrackets returns the closing bracket of every opening bracket from `from` on
+func matchBrackets(s string, from int) map[int]int {
+	brackets := map[int]int{}
+	open := []int{}
+	for j := from; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case '[':
+			brackets[j] = -1
+			open = append(open, j)
+		case ']':
+			if len(open) > 0 {
+				brackets[open[len(open)-1]] = j
+				open = open[:len(open)-1]
+			}
+		}
+	}
+
+	return brackets
+}
+
+// closingBracket returns the bracket closing the one at i, or -1
+func (x *inlineIndex) closingBracket(i int) int {
+	if x.brackets == nil {
+		x.brackets = matchBrackets(x.s, i)
+	}
+
+	if j, ok := x.brackets[i]; ok {
+		return j
+	}
+
+	// i is inside of an escape seen from the first bracket
+	return matchBrackets(x.s, i)[i]
+}
+
+// destEnd returns the end of the link destination starting at k, which is the first
+// space or the first closing parenthesis without an opening one
+func (x *inlineIndex) destEnd(k int) int {
+	if x.destEnds == nil {
+		s := x.s
+		x.destEnds = make([]int, len(s)+1)
+		x.destEnds[len(s)] = len(s)
+		for j := len(s) - 1; j >= 0; j-- {
+			switch {
+			case isSpace(s[j]) || s[j] == ')':
+				x.destEnds[j] = j
+			case s[j] == '(':
+				// the destination continues after the parenthesis closing this one
+				end := x.destEnds[j+1]
+				if end < len(s) && s[end] == ')' {
+					end = x.destEnds[end+1]
+				}
+				x.destEnds[j] = end
+			default:
+				x.destEnds[j] = x.destEnds[j+1]
+			}
+		}
+	}
+
+	return x.destEnds[k]
+}
+
+// indexByte returns the position of the first c at or after from, or -1
+func (x *inlineIndex) indexByte(from int, c byte) int {
+	if last, ok := x.bytes[c]; ok && last[0] <= from && (last[1] < 0 || last[1] >= from) {
+		return last[1]
+	}
+
+	j := strings.IndexByte(x.s[from:], c)
+	if j >= 0 {
+		j += from
+	}
+	x.bytes[c] = [2]int{from, j}
+
+	return j
+}
+
+// closingDelimiter returns the start of the delimiter closing an emphasis opened before `from`
+func (x *inlineIndex) closingDelimiter(from int, delim string) int {
+	if after, ok := x.noCloser[delim]; ok && from >= after {
+		return -1
+	}
+
+	end := closingDelimiter(x.s, from, delim)
+	if end < 0 {
+		x.noCloser[delim] = from
+	}
+
+	return end
+}
+
+// linkEnd parses `[text](destination "title")` starting at the opening bracket
+func (p *markdownParser) linkEnd(x *inlineIndex, i, lineNo int) (text, dest string, end int, ok bool) {
+	s := x.s
+	j := x.closingBracket(i)
+	if j < 0 || j >= len(s)-1 || s[j+1] != '(' {
+		return "", "", 0, false
+	}
+
+	text = s[i+1 : j]
+	k := j + 2
+	for k < len(s) && isSpace(s[k]) {
+		k++
+	}
+
+	if k < len(s) && s[k] == '<' {
+		close := x.indexByte(k, '>')
+		if close < 0 {
+			return "", "", 0, false
+		}
+		dest = s[k+1 : close]
+		k = close + 1
+	} else {
+		start := k
+		k = x.destEnd(k)
+		dest = s[start:k]
+	}
+
+	for k < len(s) && isSpace(s[k]) {
+		k++
+	}
+
+	if k < len(s) && (s[k] == '"' || s[k] == '\'' || s[k] == '(') {
+		closer := s[k]
+		if closer == '(' {
+			closer = ')'
+		}
+
+		close := x.indexByte(k+1, closer)
+		if close < 0 {
+			return "", "", 0, false
+		}
+
+		p.report.add(lineNo, RichTextNodeHyperlink, "the title of the link to %s is dropped", dest)
+		k = close + 1
+	}
+
+	for k < len(s) && isSpace(s[k]) {
+		k++
+	}
+
+	if k >= len(s) || s[k] != ')' {
+		return "", "", 0, false
+	}
+
+	return text, dest, k + 1, true
+}
+
+func (p *markdownParser) link(dest string, content []*RichTextNode) *RichTextNode {
+	if len(content) == 0 {
+		content = []*RichTextNode{NewRichTextText("")}
+	}
+
+	if m := mdHyperlinkTarget.FindStringSubmatch(dest); m != nil {
+		linkType := "Entry"
+		if m[1] == RichTextNodeAssetHyperlink {
+			linkType = "Asset"
+		}
+
+		return NewRichTextEmbed(m[1], NewLink(linkType, m[2]), content...)
+	}
+
+	return NewRichTextHyperlink(dest, content...)
+}
+
+func (p *markdownParser) inline(s string, marks []string, lineNo int) []*RichTextNode {
+	nodes := []*RichTextNode{}
+	var buf strings.Builder
+
+	flush := func() {
+		nodes = appendText(nodes, buf.String(), marks)
+		buf.Reset()
+	}
+
+	reportedHTML := false
+	index := newInlineIndex(s)
+
+	// embedStop is the position of the first brace or space after the last inline embed id
+	embedStop := -1
+
+	for i := 0; i < len(s); {
+		c := s[i]
+
+		switch {
+		case c == '\\' && i+1 < len(s) && s[i+1] == '\n':
+			buf.WriteByte('\n')
+			i += 2
+			continue
+
+		case c == '\\' && i+1 < len(s) && isASCIIPunct(s[i+1]):
+			buf.WriteByte(s[i+1])
+			i += 2
+			continue
+
+		case c == '\n':
+			text := buf.String()
+			trimmed := strings.TrimRight(text, " ")
+			buf.Reset()
+			buf.WriteString(trimmed)
+			if len(text)-len(trimmed) >= 2 {
+				buf.WriteByte('\n')
+			} else {
+				buf.WriteByte(' ')
+			}
+
+			for i++; i < len(s) && s[i] == ' '; i++ {
+			}
+			continue
+
+		case c == '`':
+			n := runLength(s, i, '`')
+			end := closingBacktick(s, i+n, n)
+			if end < 0 {
+				buf.WriteString(s[i : i+n])
+				i += n
+				continue
+			}
+
+			code := strings.Replace(s[i+n:end], "\n", " ", -1)
+			if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.TrimSpace(code) != "" {
+				code = code[1 : len(code)-1]
+			}
+
+			flush()
+			nodes = appendText(nodes, code, withMark(marks, RichTextMarkCode))
+			i = end + n
+			continue
+
+		case c == '{' && strings.HasPrefix(s[i:], mdEmbeddedInline):
+			// the end of the id is looked up once for all the embeds before it,
+			// so unclosed embeds are not scanned to the end of the text again and again
+			start := i + len(mdEmbeddedInline)
+			if embedStop < start {
+				embedStop = len(s)
+				if end := strings.IndexAny(s[start:], "}\t\n\f\r "); end >= 0 {
+					embedStop = start + end
+				}
+			}
+
+			if embedStop > start && strings.HasPrefix(s[embedStop:], "}}") {
+				flush()
+				nodes = append(nodes, NewRichTextEmbed(RichTextNodeEmbeddedEntryInline, NewLink("Entry", s[start:embedStop])))
+				i = embedStop + 2
+				continue
+			}
+
+		case c == '!' && i+1 < len(s) && s[i+1] == '[':
+			if text, dest, end, ok := p.linkEnd(index, i+1, lineNo); ok {
+				p.report.add(lineNo, "image", "the image %s is converted to a hyperlink, embed the asset instead", dest)
+
+				content := p.inline(text, marks, lineNo)
+				if len(content) == 0 {
+					content = appendText(content, dest, marks)
+				}
+
+				flush()
+				nodes = append(nodes, p.link(dest, content))
+				i = end
+				continue
+			}
+
+		case c == '[':
+			if text, dest, end, ok := p.linkEnd(index, i, lineNo); ok {
+				flush()
+				nodes = append(nodes, p.link(dest, p.inline(text, marks, lineNo)))
+				i = end
+				continue
+			}
+
+		case c == '<':
+			if m := mdAutolink.FindStringSubmatch(s[i:]); m != nil {
+				flush()
+				nodes = append(nodes, NewRichTextHyperlink(m[1], NewRichTextText(m[1], marks...)))
+				i += len(m[0])
+				continue
+			}
+
+			if m := mdEmailAutolink.FindStringSubmatch(s[i:]); m != nil {
+				flush()
+				nodes = append(nodes, NewRichTextHyperlink("mailto:"+m[1], NewRichTextText(m[1], marks...)))
+				i += len(m[0])
+				continue
+			}
+
+			if m := mdHTMLTag.FindString(s[i:]); m != "" && !reportedHTML {
+				p.report.add(lineNo, "html", "raw html %s is kept as text", m)
+				reportedHTML = true
+			}
+
+		case c == '~' && runLength(s, i, '~') == 2 && i+2 < len(s) && !isSpace(s[i+2]):
+			if end := index.closingDelimiter(i+2, "~~"); end >= 0 {
+				flush()
+				nodes = append(nodes, p.inline(s[i+2:end], withMark(marks, RichTextMarkStrikethrough), lineNo)...)
+				i = end + 2
+				continue
+			}
+
+		case c == '*' || c == '_':
+			run := runLength(s, i, c)
+			if i+run >= len(s) || isSpace(s[i+run]) || (c == '_' && i > 0 && isAlnum(s[i-1])) {
+				buf.WriteString(s[i : i+run])
+				i += run
+				continue
+			}
+
+			if run >= 2 {
+				delim := s[i : i+2]
+				if end := index.closingDelimiter(i+2, delim); end >= 0 {
+					flush()
+					nodes = append(nodes, p.inline(s[i+2:end], withMark(marks, RichTextMarkBold), lineNo)...)
+					i = end + 2
+					continue
+				}
+			}
+
+			if end := index.closingDelimiter(i+1, s[i:i+1]); end >= 0 {
+				flush()
+				nodes = append(nodes, p.inline(s[i+1:end], withMark(marks, RichTextMarkItalic), lineNo)...)
+				i = end + 1
+				continue
+			}
+
+			buf.WriteString(s[i : i+run])
+			i += run
+			continue
+		}
+
+		buf.WriteByte(c)
+		i++
+	}
+
+	flush()
+
+	// merge the text of nested emphasis which ended up with the same marks
+	merged := []*RichTextNode{}
+	for _, node := range nodes {
+		if node.IsText() {
+			marks := []string{}
+			for _, mark := range node.Marks {
+				marks = append(marks, mark.Type)
+			}
+			merged = appendText(merged, node.Value, marks)
+			continue
+		}
+		merged = append(merged, node)
+	}
+
+	return merged
+}
+
+var mdLineStart = regexp.MustCompile(`^(?:[#>+=-]|\d+[.)])`)
+
+type markdownWriter struct {
+	report   *RichTextConversionReport
+	reported map[string]bool
+}
+
+// RichTextToMarkdown converts a rich text document to CommonMark, with GitHub tables and strikethrough.
+// Embedded entries and assets and links to them are written as the placeholders
+// understood by MarkdownToRichText, code blocks are paragraphs holding a single code text.
+func RichTextToMarkdown(node *RichTextNode) (string, *RichTextConversionReport) {
+	w := &markdownWriter{
+		report:   &RichTextConversionReport{},
+		reported: map[string]bool{},
+	}
+
+	if node.NodeType == RichTextNodeDocument {
+		return w.blocks(node.Content), w.report
+	}
+
+	return w.block(node), w.report
+}
+
+// lossy reports a construct once per conversion
+func (w *markdownWriter) lossy(construct, format string, args ...interface{}) {
+	if w.reported[construct] {
+		return
+	}
+
+	w.reported[construct] = true
+	w.report.add(0, construct, format, args...)
+}
+
+func (w *markdownWriter) blocks(nodes []*RichTextNode) string {
+	parts := []string{}
+	for _, node := range nodes {
+		parts = append(parts, w.block(node))
+	}
+
+	return strings.Join(parts, "\n\n")
+}
+
+func prefixLines(text, first, rest string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		prefix := rest
+		if i == 0 {
+			prefix = first
+		}
+
+		if line == "" {
+			prefix = strings.TrimRight(prefix, " ")
+		}
+
+		lines[i] = prefix + line
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func targetID(node *RichTextNode) string {
+	if node.Data == nil || node.Data.Target == nil || node.Data.Target.Sys == nil {
+		return ""
+	}
+
+	return node.Data.Target.Sys.ID
+}
+
+func (w *markdownWriter) block(node *RichTextNode) string {
+	switch node.NodeType {
+	case RichTextNodeParagraph:
+		if len(node.Content) == 1 && node.Content[0].IsText() && node.Content[0].HasMark(RichTextMarkCode) &&
+			strings.Contains(node.Content[0].Value, "\n") {
+			return w.codeBlock(node.Content[0])
+		}
+
+		return w.paragraph(node)
+
+	case RichTextNodeHeading1, RichTextNodeHeading2, RichTextNodeHeading3,
+		RichTextNodeHeading4, RichTextNodeHeading5, RichTextNodeHeading6:
+		level, _ := strconv.Atoi(strings.TrimPrefix(node.NodeType, "heading-"))
+		text := w.inlines(node.Content)
+		if strings.Contains(text, "\n") {
+			w.lossy(node.NodeType, "line breaks in headings are replaced by spaces")
+			text = strings.Replace(text, "\\\n", " ", -1)
+		}
+
+		return strings.Repeat("#", level) + " " + text
+
+	case RichTextNodeHR:
+		return "---"
+
+	case RichTextNodeBlockquote:
+		return prefixLines(w.blocks(node.Content), "> ", "> ")
+
+	case RichTextNodeOrderedList, RichTextNodeUnorderedList:
+		return w.list(node)
+
+	case RichTextNodeTable:
+		return w.table(node)
+
+	case RichTextNodeEmbeddedEntryBlock, RichTextNodeEmbeddedAssetBlock:
+		return fmt.Sprintf("{{%s:%s}}", node.NodeType, targetID(node))
+
+	case RichTextNodeText, RichTextNodeHyperlink, RichTextNodeEntryHyperlink,
+		RichTextNodeAssetHyperlink, RichTextNodeEmbeddedEntryInline:
+		return w.inlines([]*RichTextNode{node})
+	}
+
+	w.lossy(node.NodeType, "%s nodes have no markdown equivalent, only their content is kept", node.NodeType)
+	return w.blocks(node.Content)
+}
+
+func (w *markdownWriter) paragraph(node *RichTextNode) string {
+	lines := strings.Split(w.inlines(node.Content), "\n")
+	for i, line := range lines {
+		// escape text which would otherwise start a block
+		if loc := mdLineStart.FindStringIndex(line); loc != nil {
+			lines[i] = line[:loc[1]-1] + `\` + line[loc[1]-1:]
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func longestRun(s string, c byte) int {
+	longest := 0
+	for i := 0; i < len(s); i++ {
+		if n := runLength(s, i, c); n > longest {
+			longest = n
+		}
+	}
+
+	return longest
+}
+
+func (w *markdownWriter) codeBlock(node *RichTextNode) string {
+	w.marksLost(node, RichTextMarkCode)
+
+	fence := strings.Repeat("`", longestRun(node.Value, '`')+1)
+	if len(fence) < 3 {
+		fence = "```"
+	}
+
+	return fence + "\n" + node.Value + "\n" + fence
+}
+
+func (w *markdownWriter) list(node *RichTextNode) string {
+	items := []string{}
+	for i, item := range node.Content {
+		marker := "- "
+		if node.NodeType == RichTextNodeOrderedList {
+			marker = strconv.Itoa(i+1) + ". "
+		}
+
+		content := item.Content
+		if item.NodeType != RichTextNodeListItem {
+			content = []*RichTextNode{item}
+		}
+
+		// nested lists follow their paragraph directly to keep the list tight
+		var body strings.Builder
+		for j, child := range content {
+			if j > 0 {
+				if child.NodeType == RichTextNodeOrderedList || child.NodeType == RichTextNodeUnorderedList {
+					body.WriteString("\n")
+				} else {
+					body.WriteString("\n\n")
+				}
+			}
+			body.WriteString(w.block(child))
+		}
+
+		items = append(items, prefixLines(body.String(), marker, strings.Repeat(" ", len(marker))))
+	}
+
+	return strings.Join(items, "\n")
+}
+
+func (w *markdownWriter) table(node *RichTextNode) string {
+	rows := [][]string{}
+	columns := 0
+	headerRow := len(node.Content) > 0
+
+	for i, row := range node.Content {
+		cells := []string{}
+		for _, cell := range row.Content {
+			if i == 0 && cell.NodeType != RichTextNodeTableHeaderCell {
+				headerRow = false
+			}
+
+			if cell.Data != nil && len(cell.Data.Extra) > 0 {
+				w.lossy(RichTextNodeTable, "cell spans are dropped")
+			}
+
+			if len(cell.Content) > 1 {
+				w.lossy(RichTextNodeTableCell, "table cells with several paragraphs are joined into one")
+			}
+
+			parts := []string{}
+			for _, child := range cell.Content {
+				if child.NodeType != RichTextNodeParagraph {
+					w.lossy(RichTextNodeTableCell, "table cells can only hold paragraphs in markdown")
+				}
+				parts = append(parts, w.inlines(child.Content))
+			}
+
+			text := strings.Join(parts, " ")
+			if strings.Contains(text, "\n") {
+				w.lossy(RichTextNodeTableCell, "line breaks in table cells are replaced by spaces")
+				text = strings.Replace(text, "\\\n", " ", -1)
+			}
+
+			cells = append(cells, text)
+		}
+
+		if len(cells) > columns {
+			columns = len(cells)
+		}
+		rows = append(rows, cells)
+	}
+
+	if !headerRow {
+		w.lossy(RichTextNodeTableHeaderCell, "markdown tables need a header, the first row is written as header")
+	}
+
+	formatRow := func(cells []string) string {
+		for len(cells) < columns {
+			cells = append(cells, "")
+		}
+
+		return "| " + strings.Join(cells, " | ") + " |"
+	}
+
+	delimiter := []string{}
+	for i := 0; i < columns; i++ {
+		delimiter = append(delimiter, "---")
+	}
+
+	lines := []string{}
+	for i, cells := range rows {
+		lines = append(lines, formatRow(cells))
+		if i == 0 {
+			lines = append(lines, formatRow(delimiter))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (w *markdownWriter) inlines(nodes []*RichTextNode) string {
+	var b strings.Builder
+
+	for _, node := range mergeTexts(nodes) {
+		switch node.NodeType {
+		case RichTextNodeText:
+			b.WriteString(w.text(node))
+
+		case RichTextNodeHyperlink:
+			uri := ""
+			if node.Data != nil {
+				uri = node.Data.URI
+			}
+
+			if strings.ContainsAny(uri, " ()<>") {
+				uri = "<" + uri + ">"
+			}
+
+			b.WriteString("[" + w.inlines(node.Content) + "](" + uri + ")")
+
+		case RichTextNodeEntryHyperlink, RichTextNodeAssetHyperlink:
+			b.WriteString(fmt.Sprintf("[%s]({{%s:%s}})", w.inlines(node.Content), node.NodeType, targetID(node)))
+
+		case RichTextNodeEmbeddedEntryInline:
+			b.WriteString(fmt.Sprintf("{{%s:%s}}", node.NodeType, targetID(node)))
+
+		default:
+			w.lossy(node.NodeType, "%s nodes have no markdown equivalent, only their content is kept", node.NodeType)
+			b.WriteString(w.inlines(node.Content))
+		}
+	}
+
+	return b.String()
+}
+
+// mergeTexts joins adjacent text nodes with the same marks, which markdown can't delimit
+func mergeTexts(nodes []*RichTextNode) []*RichTextNode {
+	merged := []*RichTextNode{}
+	for _, node := range nodes {
+		if !node.IsText() {
+			merged = append(merged, node)
+			continue
+		}
+
+		marks := []string{}
+		for _, mark := range node.Marks {
+			marks = append(marks, mark.Type)
+		}
+
+		if len(merged) > 0 {
+			last := merged[len(merged)-1]
+			if last.IsText() && sameMarks(last, marks) {
+				merged[len(merged)-1] = NewRichTextText(last.Value+node.Value, marks...)
+				continue
+			}
+		}
+
+		merged = append(merged, node)
+	}
+
+	return merged
+}
+
+// marksLost reports the marks of the node which markdown can't express
+func (w *markdownWriter) marksLost(node *RichTextNode, supported ...string) {
+	for _, mark := range node.Marks {
+		known := false
+		for _, s := range supported {
+			known = known || mark.Type == s
+		}
+
+		if !known {
+			w.lossy(mark.Type, "the %s mark has no markdown equivalent and is dropped", mark.Type)
+		}
+	}
+}
+
+func escapeMarkdown(text string) string {
+	var b strings.Builder
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		if strings.IndexByte("\\`*_[]<>~|", c) >= 0 || (c == '{' && i+1 < len(text) && text[i+1] == '{') {
+			b.WriteByte('\\')
+		}
+
+		if c == '\n' {
+			b.WriteString("\\\n")
+			continue
+		}
+
+		b.WriteByte(c)
+	}
+
+	return b.String()
+}
+
+func codeSpan(text string) string {
+	fence := strings.Repeat("`", longestRun(text, '`')+1)
+	if strings.HasPrefix(text, "`") || strings.HasSuffix(text, "`") ||
+		(strings.HasPrefix(text, " ") && strings.HasSuffix(text, " ") && strings.TrimSpace(text) != "") {
+		text = " " + text + " "
+	}
+
+	return fence + text + fence
+}
+
+func (w *markdownWriter) text(node *RichTextNode) string {
+	w.marksLost(node, RichTextMarkBold, RichTextMarkItalic, RichTextMarkCode, RichTextMarkStrikethrough)
+
+	value := node.Value
+	if node.HasMark(RichTextMarkCode) && strings.Contains(value, "\n") {
+		w.lossy(RichTextMarkCode, "line breaks in inline code are replaced by spaces")
+		value = strings.Replace(value, "\n", " ", -1)
+	}
+
+	// emphasis can't start or end with whitespace, keep it outside of the delimiters
+	core := strings.TrimSpace(value)
+	if core == "" {
+		return escapeMarkdown(value)
+	}
+
+	start := strings.Index(value, core)
+	leading, trailing := value[:start], value[start+len(core):]
+
+	if node.HasMark(RichTextMarkCode) {
+		core = codeSpan(core)
+	} else {
+		core = escapeMarkdown(core)
+	}
+
+	if node.HasMark(RichTextMarkStrikethrough) {
+		core = "~~" + core + "~~"
+	}
+
+	if node.HasMark(RichTextMarkItalic) {
+		core = "*" + core + "*"
+	}
+
+	if node.HasMark(RichTextMarkBold) {
+		core = "**" + core + "**"
+	}
+
+	return escapeMarkdown(leading) + core + escapeMarkdown(trailing)
+}
@@ -0,0 +1,233 @@
+package contentful
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkdownToRichText(t *testing.T) {
+	assert := assert.New(t)
+
+	doc, report := MarkdownToRichText(readTestData("rich_text.md"))
+	assert.True(report.Lossless())
+
+	types := []string{}
+	for _, node := range doc.Content {
+		types = append(types, node.NodeType)
+	}
+	assert.Equal([]string{
+		RichTextNodeHeading1,
+		RichTextNodeParagraph,
+		RichTextNodeUnorderedList,
+		RichTextNodeOrderedList,
+		RichTextNodeBlockquote,
+		RichTextNodeParagraph,
+		RichTextNodeEmbeddedEntryBlock,
+		RichTextNodeTable,
+		RichTextNodeHR,
+		RichTextNodeHeading2,
+		RichTextNodeEmbeddedAssetBlock,
+	}, types)
+
+	paragraph := doc.Content[1].Content
+	assert.Equal("A cat with a ", paragraph[0].Value)
+	assert.Equal("pop-tart", paragraph[1].Value)
+	assert.True(paragraph[1].HasMark(RichTextMarkBold))
+	assert.True(paragraph[1].HasMark(RichTextMarkItalic))
+	assert.Equal(" body, ", paragraph[2].Value)
+	assert.Equal("loud", paragraph[3].Value)
+	assert.True(paragraph[3].HasMark(RichTextMarkStrikethrough))
+	assert.Equal("purr()", paragraph[5].Value)
+	assert.True(paragraph[5].HasMark(RichTextMarkCode))
+	assert.Equal(RichTextNodeHyperlink, paragraph[7].NodeType)
+	assert.Equal("https://en.wikipedia.org/wiki/Nyan_Cat", paragraph[7].Data.URI)
+	assert.Equal("Wikipedia", paragraph[7].PlainText())
+	assert.Equal(RichTextNodeEntryHyperlink, paragraph[9].NodeType)
+	assert.Equal(NewLink("Entry", "happycat"), paragraph[9].Data.Target)
+	assert.Equal(RichTextNodeEmbeddedEntryInline, paragraph[11].NodeType)
+	assert.Equal(" *escaped*\nand a line break", paragraph[12].Value)
+
+	bullets := doc.Content[2].Content
+	assert.Equal(2, len(bullets))
+	assert.Equal("rainbow trail", bullets[0].Content[0].PlainText())
+	assert.Equal(RichTextNodeUnorderedList, bullets[1].Content[1].NodeType)
+	assert.Equal("sparkles", bullets[1].Content[1].PlainText())
+
+	assert.Equal("first\nsecond", doc.Content[3].PlainText())
+	assert.Equal("Meow meow", doc.Content[4].PlainText())
+
+	code := doc.Content[5].Content[0]
+	assert.True(code.HasMark(RichTextMarkCode))
+	assert.Equal("cat := Cat{}\ncat.Fly()", code.Value)
+
+	assert.Equal(NewLink("Entry", "grumpycat"), doc.Content[6].Data.Target)
+
+	rows := doc.Content[7].Content
+	assert.Equal(3, len(rows))
+	assert.Equal(RichTextNodeTableHeaderCell, rows[0].Content[0].NodeType)
+	assert.Equal(RichTextNodeTableCell, rows[1].Content[0].NodeType)
+	assert.Equal("a|b", rows[2].Content[1].PlainText())
+
+	assert.Equal(NewLink("Asset", "nyancat"), doc.Content[10].Data.Target)
+}
+
+func TestMarkdownToRichTextReport(t *testing.T) {
+	assert := assert.New(t)
+
+	doc, report := MarkdownToRichText("![Nyan](https://example.com/nyan.gif)\n\n```go\nfmt.Println()\n```\n\n| a | b |\n|:--|--:|\n| 1 | 2 | 3 |\n\n3. three\n4. four\n\n> # quoted heading\n\n<b>bold</b>")
+
+	constructs := map[string]int{}
+	for _, issue := range report.Issues {
+		constructs[issue.Construct] = issue.Line
+	}
+
+	assert.False(report.Lossless())
+	assert.Equal(map[string]int{
+		"image":                 1,
+		"code":                  3,
+		RichTextNodeTable:       8,
+		RichTextNodeTableRow:    9,
+		RichTextNodeOrderedList: 11,
+		RichTextNodeBlockquote:  14,
+		"html":                  16,
+	}, constructs)
+
+	assert.Equal(RichTextNodeHyperlink, doc.Content[0].Content[0].NodeType)
+	assert.Equal("Nyan", doc.Content[0].PlainText())
+	assert.Equal("fmt.Println()", doc.Content[1].PlainText())
+	assert.Equal("<b>bold</b>", doc.Content[5].PlainText())
+}
+
+func TestMarkdownToRichTextEmbeddedInline(t *testing.T) {
+	assert := assert.New(t)
+
+	doc, _ := MarkdownToRichText("Meet {{embedded-entry-inline:a b}} and {{embedded-entry-inline:nyancat}}{{embedded-entry-inline:}}")
+	paragraph := doc.Content[0]
+	assert.Equal(3, len(paragraph.Content))
+	assert.Equal("Meet {{embedded-entry-inline:a b}} and ", paragraph.Content[0].Value)
+	assert.Equal(NewLink("Entry", "nyancat"), paragraph.Content[1].Data.Target)
+	assert.Equal("{{embedded-entry-inline:}}", paragraph.Content[2].Value)
+
+	// unclosed embeds are kept as text, without scanning the rest of the text for each of them
+	unclosed := strings.Repeat("{{embedded-entry-inline:", 50000)
+	doc, _ = MarkdownToRichText(unclosed + " {{embedded-entry-inline:grumpycat}}")
+	paragraph = doc.Content[0]
+	assert.Equal(2, len(paragraph.Content))
+	assert.Equal(unclosed+" ", paragraph.Content[0].Value)
+	assert.Equal(NewLink("Entry", "grumpycat"), paragraph.Content[1].Data.Target)
+}
+
+func TestMarkdownToRichTextUnclosed(t *testing.T) {
+	assert := assert.New(t)
+
+	// unclosed emphasis and links are kept as text, in linear time
+	for _, pattern := range []string{"_a ", "*a ", "**a ", "~~a ", "[a](", "[", "[a](<", "[a](b (", "[a](b \"", "`a"} {
+		text := strings.Repeat(pattern, 100000)
+
+		start := time.Now()
+		doc, report := MarkdownToRichText(text)
+		elapsed := time.Since(start)
+
+		assert.True(elapsed < time.Second, "%q took %s", pattern, elapsed)
+		assert.NotNil(report)
+		assert.Equal(RichTextNodeParagraph, doc.Content[0].NodeType, pattern)
+	}
+
+	doc, _ := MarkdownToRichText("_a [a](b(c)(d)) [[a]](<e>) [b](f (g)) *c*")
+	assert.Equal(
+		NewRichTextNode(RichTextNodeParagraph,
+			NewRichTextText("_a "),
+			NewRichTextHyperlink("b(c)(d)", NewRichTextText("a")),
+			NewRichTextText(" "),
+			NewRichTextHyperlink("e", NewRichTextText("[a]")),
+			NewRichTextText(" "),
+			NewRichTextHyperlink("f", NewRichTextText("b")),
+			NewRichTextText(" "),
+			NewRichTextText("c", RichTextMarkItalic),
+		),
+		doc.Content[0],
+	)
+}
+
+func TestRichTextToMarkdown(t *testing.T) {
+	assert := assert.New(t)
+
+	markdown, report := RichTextToMarkdown(richTextFromTestData(t))
+	assert.Equal(2, len(report.Issues))
+	assert.Equal(RichTextNodeTable, report.Issues[0].Construct)
+	assert.Equal(RichTextNodeTableHeaderCell, report.Issues[1].Construct)
+	assert.Equal(
+		"## Nyan Cat\n\n"+
+			"A cat with a ***pop-tart*** body, see [Wikipedia](https://en.wikipedia.org/wiki/Nyan_Cat?a=1&b=2) & {{embedded-entry-inline:happycat}}\n\n"+
+			"- rainbow\n\n"+
+			"| Lives | `9` |\n| --- | --- |\n\n"+
+			"---\n\n"+
+			"{{embedded-asset-block:nyancat}}",
+		markdown,
+	)
+}
+
+func TestRichTextToMarkdownReport(t *testing.T) {
+	assert := assert.New(t)
+
+	doc := NewRichTextDocument(
+		NewRichTextNode(RichTextNodeParagraph,
+			NewRichTextText("under", RichTextMarkUnderline),
+			NewRichTextText(" and "),
+			NewRichTextText("over", RichTextMarkUnderline, RichTextMarkSuperscript),
+		),
+		NewRichTextNode(RichTextNodeTable,
+			NewRichTextNode(RichTextNodeTableRow,
+				NewRichTextNode(RichTextNodeTableCell, NewRichTextNode(RichTextNodeParagraph, NewRichTextText("cell"))),
+			),
+		),
+	)
+
+	markdown, report := RichTextToMarkdown(doc)
+	assert.Equal("under and over\n\n| cell |\n| --- |", markdown)
+
+	constructs := []string{}
+	for _, issue := range report.Issues {
+		assert.Equal(0, issue.Line)
+		constructs = append(constructs, issue.Construct)
+	}
+	assert.Equal([]string{RichTextMarkUnderline, RichTextMarkSuperscript, RichTextNodeTableHeaderCell}, constructs)
+}
+
+func TestRichTextMarkdownRoundTrip(t *testing.T) {
+	assert := assert.New(t)
+
+	doc, report := MarkdownToRichText(readTestData("rich_text.md"))
+	assert.True(report.Lossless())
+
+	markdown, report := RichTextToMarkdown(doc)
+	assert.True(report.Lossless())
+
+	parsed, report := MarkdownToRichText(markdown)
+	assert.True(report.Lossless())
+	assert.Equal(doc, parsed)
+
+	// text which looks like markdown survives
+	doc = NewRichTextDocument(
+		NewRichTextNode(RichTextNodeParagraph,
+			NewRichTextText("# not a heading *nor emphasis* {{embedded-entry-inline:nope}} "),
+			NewRichTextText(" spaced ", RichTextMarkBold),
+			NewRichTextText("a `tick`", RichTextMarkCode),
+		),
+		NewRichTextNode(RichTextNodeParagraph, NewRichTextText("1. not a list\n- nor this")),
+		NewRichTextNode(RichTextNodeParagraph, NewRichTextText("line one\n```\nline three", RichTextMarkCode)),
+	)
+
+	markdown, report = RichTextToMarkdown(doc)
+	assert.True(report.Lossless())
+
+	parsed, report = MarkdownToRichText(markdown)
+	assert.True(report.Lossless())
+	assert.Equal(doc.PlainText(), parsed.PlainText())
+	assert.Equal(3, len(parsed.Content))
+	assert.True(parsed.Content[0].Content[1].HasMark(RichTextMarkBold))
+	assert.True(parsed.Content[0].Content[3].HasMark(RichTextMarkCode))
+}
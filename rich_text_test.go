@@ -0,0 +1,172 @@
+package contentful
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func richTextFromTestData(t *testing.T) *RichTextNode {
+	doc, err := ParseRichText([]byte(readTestData("rich_text.json")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return doc
+}
+
+func TestParseRichText(t *testing.T) {
+	assert := assert.New(t)
+
+	doc := richTextFromTestData(t)
+	assert.Equal(RichTextNodeDocument, doc.NodeType)
+	assert.Equal(6, len(doc.Content))
+
+	paragraph := doc.Content[1]
+	assert.True(paragraph.Content[1].HasMark(RichTextMarkBold))
+	assert.False(paragraph.Content[0].HasMark(RichTextMarkBold))
+	assert.Equal("https://en.wikipedia.org/wiki/Nyan_Cat?a=1&b=2", paragraph.Content[3].Data.URI)
+	assert.Equal(NewLink("Entry", "happycat"), paragraph.Content[5].Data.Target)
+
+	cell := doc.Content[3].Content[0].Content[1]
+	assert.Equal(RichTextNodeTableCell, cell.NodeType)
+	assert.Equal(json.RawMessage("2"), cell.Data.Extra["colspan"])
+
+	_, err := ParseRichText([]byte(`{"nodeType": "paragraph", "data": {}, "content": []}`))
+	assert.NotNil(err)
+
+	_, err = ParseRichText([]byte(`{"data": {}, "content": []}`))
+	assert.NotNil(err)
+}
+
+func TestRichTextJSONRoundTrip(t *testing.T) {
+	assert := assert.New(t)
+
+	doc := richTextFromTestData(t)
+
+	data, err := json.Marshal(doc)
+	assert.Nil(err)
+
+	var expected, actual interface{}
+	assert.Nil(json.Unmarshal([]byte(readTestData("rich_text.json")), &expected))
+	assert.Nil(json.Unmarshal(data, &actual))
+	assert.Equal(expected, actual)
+}
+
+func TestRichTextBuilders(t *testing.T) {
+	assert := assert.New(t)
+
+	doc := NewRichTextDocument(
+		NewRichTextNode(RichTextNodeParagraph,
+			NewRichTextText("Hello "),
+			NewRichTextText("world", RichTextMarkBold),
+			NewRichTextHyperlink("https://example.com", NewRichTextText("!")),
+		),
+		NewRichTextEmbed(RichTextNodeEmbeddedEntryBlock, NewLink("Entry", "cat")),
+		NewRichTextNode(RichTextNodeHR),
+	)
+
+	data, err := json.Marshal(doc)
+	assert.Nil(err)
+
+	parsed, err := ParseRichText(data)
+	assert.Nil(err)
+	assert.Equal(doc, parsed)
+
+	assert.Equal("Hello world!\n\n", doc.PlainText())
+
+	types := []string{}
+	doc.Walk(func(node *RichTextNode) bool {
+		types = append(types, node.NodeType)
+		return node.NodeType != RichTextNodeParagraph
+	})
+	assert.Equal([]string{RichTextNodeDocument, RichTextNodeParagraph, RichTextNodeEmbeddedEntryBlock, RichTextNodeHR}, types)
+}
+
+func TestEntryFieldRichTextDocument(t *testing.T) {
+	assert := assert.New(t)
+
+	var raw interface{}
+	assert.Nil(json.Unmarshal([]byte(readTestData("rich_text.json")), &raw))
+
+	entry := &Entry{Fields: map[string]interface{}{
+		"body": map[string]interface{}{"en-US": raw},
+	}}
+
+	doc, err := NewEntryField(entry, &Field{ID: "body", Type: FieldTypeRichText}, "body").RichTextDocument("en-US")
+	assert.Nil(err)
+	assert.Equal("Nyan Cat", doc.Content[0].PlainText())
+}
+
+func TestRichTextHTMLRenderer(t *testing.T) {
+	assert := assert.New(t)
+
+	doc := richTextFromTestData(t)
+
+	rendered, err := NewRichTextHTMLRenderer().Render(doc)
+	assert.Nil(err)
+	assert.Equal(
+		"<h2>Nyan Cat</h2>"+
+			`<p>A cat with a <i><b>pop-tart</b></i> body, see <a href="https://en.wikipedia.org/wiki/Nyan_Cat?a=1&amp;b=2">Wikipedia</a> &amp; <span>type: embedded-entry-inline id: happycat</span></p>`+
+			"<ul><li><p>rainbow</p></li></ul>"+
+			"<table><tr><th><p>Lives</p></th><td><p><code>9</code></p></td></tr></table>"+
+			"<hr/>"+
+			"<div>type: embedded-asset-block id: nyancat</div>",
+		rendered,
+	)
+}
+
+func TestRichTextHTMLRendererHyperlinkSchemes(t *testing.T) {
+	assert := assert.New(t)
+
+	renderer := NewRichTextHTMLRenderer()
+
+	for uri, expected := range map[string]string{
+		"https://nyan.cat":          `<a href="https://nyan.cat">cat</a>`,
+		"HTTP://nyan.cat":           `<a href="HTTP://nyan.cat">cat</a>`,
+		"mailto:cat@nyan.cat":       `<a href="mailto:cat@nyan.cat">cat</a>`,
+		"/cats?a=1&b=2":             `<a href="/cats?a=1&amp;b=2">cat</a>`,
+		"#nyan":                     `<a href="#nyan">cat</a>`,
+		"javascript:alert(1)":       "cat",
+		" JavaScript:alert(1)":      "cat",
+		"java\tscript:alert(1)":     "cat",
+		"data:text/html,<b>cat</b>": "cat",
+		"vbscript:msgbox(1)":        "cat",
+	} {
+		rendered, err := renderer.Render(NewRichTextHyperlink(uri, NewRichTextText("cat")))
+		assert.Nil(err)
+		assert.Equal(expected, rendered, uri)
+	}
+}
+
+func TestRichTextHTMLRendererOverrides(t *testing.T) {
+	assert := assert.New(t)
+
+	titles := map[string]string{"happycat": "Happy Cat"}
+
+	renderer := NewRichTextHTMLRenderer()
+	renderer.NodeRenderers[RichTextNodeEmbeddedEntryInline] = func(node *RichTextNode, children string) (string, error) {
+		title, ok := titles[node.Data.Target.Sys.ID]
+		if !ok {
+			return "", fmt.Errorf("unknown entry %s", node.Data.Target.Sys.ID)
+		}
+
+		return `<cite>` + title + `</cite>`, nil
+	}
+	renderer.MarkRenderers[RichTextMarkBold] = func(text string) string {
+		return "<strong>" + text + "</strong>"
+	}
+	delete(renderer.NodeRenderers, RichTextNodeParagraph)
+
+	doc := richTextFromTestData(t)
+
+	rendered, err := renderer.Render(doc.Content[1])
+	assert.Nil(err)
+	assert.Equal(`A cat with a <i><strong>pop-tart</strong></i> body, see <a href="https://en.wikipedia.org/wiki/Nyan_Cat?a=1&amp;b=2">Wikipedia</a> &amp; <cite>Happy Cat</cite>`, rendered)
+
+	delete(titles, "happycat")
+	_, err = renderer.Render(doc)
+	assert.NotNil(err)
+}
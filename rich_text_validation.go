@@ -0,0 +1,162 @@
+package contentful
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// richTextAlwaysEnabled node types are part of every document and can't be disabled
+var richTextAlwaysEnabled = map[string]bool{
+	RichTextNodeDocument:        true,
+	RichTextNodeParagraph:       true,
+	RichTextNodeText:            true,
+	RichTextNodeListItem:        true,
+	RichTextNodeTableRow:        true,
+	RichTextNodeTableCell:       true,
+	RichTextNodeTableHeaderCell: true,
+}
+
+// RichTextValidationError describes a node of a rich text document which violates a field validation
+type RichTextValidationError struct {
+	// Path of the node in the document, in the format of the api, e.g. ["content", 1, "content", 0]
+	Path     []interface{}
+	NodeType string
+	// Validation is the name of the violated validation: enabledNodeTypes, enabledMarks or nodes
+	Validation string
+	Message    string
+}
+
+func (e RichTextValidationError) Error() string {
+	path := []string{}
+	for _, segment := range e.Path {
+		path = append(path, fmt.Sprint(segment))
+	}
+
+	return fmt.Sprintf("rich text %s at [%s] fails %s validation: %s", e.NodeType, strings.Join(path, "."), e.Validation, e.Message)
+}
+
+type richTextValidator struct {
+	nodeTypes        map[string]bool
+	nodeTypesMessage string
+	marks            map[string]bool
+	marksMessage     string
+	nodes            map[string][]FieldValidation
+	counts           map[string]int
+	errors           []RichTextValidationError
+}
+
+func stringSet(values []string) map[string]bool {
+	set := map[string]bool{}
+	for _, value := range values {
+		set[value] = true
+	}
+
+	return set
+}
+
+// ValidateRichText checks a rich text document against the enabledNodeTypes, enabledMarks and
+// nodes validations of its field and returns every violation found.
+// The content types of embedded and linked entries need the entries themselves and are not checked.
+func ValidateRichText(doc *RichTextNode, validations []FieldValidation) []RichTextValidationError {
+	v := &richTextValidator{
+		nodes:  map[string][]FieldValidation{},
+		counts: map[string]int{},
+		errors: []RichTextValidationError{},
+	}
+
+	for _, validation := range validations {
+		switch validation := validation.(type) {
+		case FieldValidationEnabledNodeTypes:
+			v.nodeTypes, v.nodeTypesMessage = stringSet(validation.NodeTypes), validation.ErrorMessage
+		case *FieldValidationEnabledNodeTypes:
+			v.nodeTypes, v.nodeTypesMessage = stringSet(validation.NodeTypes), validation.ErrorMessage
+		case FieldValidationEnabledMarks:
+			v.marks, v.marksMessage = stringSet(validation.Marks), validation.ErrorMessage
+		case *FieldValidationEnabledMarks:
+			v.marks, v.marksMessage = stringSet(validation.Marks), validation.ErrorMessage
+		case FieldValidationNodes:
+			v.nodes = validation.Nodes
+		case *FieldValidationNodes:
+			v.nodes = validation.Nodes
+		}
+	}
+
+	if doc.NodeType != RichTextNodeDocument {
+		v.fail([]interface{}{}, doc.NodeType, "nodeType", "", "the root of a rich text field must be a document")
+		return v.errors
+	}
+
+	v.walk(doc, []interface{}{})
+	v.checkSizes()
+
+	return v.errors
+}
+
+func (v *richTextValidator) fail(path []interface{}, nodeType, validation, message, fallback string) {
+	if message == "" {
+		message = fallback
+	}
+
+	v.errors = append(v.errors, RichTextValidationError{
+		Path:       path,
+		NodeType:   nodeType,
+		Validation: validation,
+		Message:    message,
+	})
+}
+
+func (v *richTextValidator) walk(node *RichTextNode, path []interface{}) {
+	v.counts[node.NodeType]++
+
+	if v.nodeTypes != nil && !richTextAlwaysEnabled[node.NodeType] && !v.nodeTypes[node.NodeType] {
+		v.fail(path, node.NodeType, "enabledNodeTypes", v.nodeTypesMessage,
+			fmt.Sprintf("node type %s is not enabled", node.NodeType))
+	}
+
+	if v.marks != nil && node.IsText() {
+		for _, mark := range node.Marks {
+			if !v.marks[mark.Type] {
+				v.fail(path, node.NodeType, "enabledMarks", v.marksMessage,
+					fmt.Sprintf("mark %s is not enabled", mark.Type))
+			}
+		}
+	}
+
+	for i, child := range node.Content {
+		childPath := append(append([]interface{}{}, path...), "content", i)
+		v.walk(child, childPath)
+	}
+}
+
+// checkSizes compares the number of nodes of each type to the size validations of the type
+func (v *richTextValidator) checkSizes() {
+	nodeTypes := []string{}
+	for nodeType := range v.nodes {
+		nodeTypes = append(nodeTypes, nodeType)
+	}
+	sort.Strings(nodeTypes)
+
+	for _, nodeType := range nodeTypes {
+		for _, validation := range v.nodes[nodeType] {
+			var size FieldValidationSize
+			switch validation := validation.(type) {
+			case FieldValidationSize:
+				size = validation
+			case *FieldValidationSize:
+				size = *validation
+			default:
+				continue
+			}
+
+			if size.Size == nil {
+				continue
+			}
+
+			if !inMinMax(float64(v.counts[nodeType]), size.Size) {
+				v.fail([]interface{}{}, nodeType, "nodes", size.ErrorMessage,
+					fmt.Sprintf("%d %s nodes are not %s", v.counts[nodeType], nodeType, describeMinMax(size.Size)))
+			}
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package contentful
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func richTextFieldFromTestData(t *testing.T) *Field {
+	var ct ContentType
+	if err := json.Unmarshal([]byte(readTestData("content_type_with_validations.json")), &ct); err != nil {
+		t.Fatal(err)
+	}
+
+	return ct.Field("Kx2y0Rp6aq3T8ZWu")
+}
+
+func TestRichTextFieldValidationsUnmarshal(t *testing.T) {
+	assert := assert.New(t)
+
+	field := richTextFieldFromTestData(t)
+	assert.Equal(3, len(field.Validations))
+
+	nodeTypes, ok := field.Validations[0].(FieldValidationEnabledNodeTypes)
+	assert.True(ok)
+	assert.Equal(5, len(nodeTypes.NodeTypes))
+	assert.Equal("rich-text only allows second level headings, lists, links and embedded entries", nodeTypes.ErrorMessage)
+
+	marks, ok := field.Validations[1].(FieldValidationEnabledMarks)
+	assert.True(ok)
+	assert.Equal([]string{RichTextMarkBold, RichTextMarkItalic}, marks.Marks)
+
+	nodes, ok := field.Validations[2].(FieldValidationNodes)
+	assert.True(ok)
+	assert.Equal(2, len(nodes.Nodes[RichTextNodeEmbeddedEntryBlock]))
+	_, ok = nodes.Nodes[RichTextNodeEmbeddedEntryBlock][0].(FieldValidationLink)
+	assert.True(ok)
+	_, ok = nodes.Nodes[RichTextNodeEmbeddedEntryBlock][1].(FieldValidationSize)
+	assert.True(ok)
+	assert.Equal([]FieldValidation{}, nodes.Nodes[RichTextNodeEntryHyperlink])
+}
+
+func TestRichTextFieldValidationsRoundTrip(t *testing.T) {
+	var err error
+	assert := assert.New(t)
+
+	var upserted map[string]interface{}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Nil(json.NewDecoder(r.Body).Decode(&upserted))
+		fmt.Fprintln(w, string(readTestData("content_type_with_validations.json")))
+	})
+
+	// test server
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	field := richTextFieldFromTestData(t)
+	ct := &ContentType{
+		Sys:    &Sys{ID: "validationsTest", Version: 1},
+		Name:   "validations-test",
+		Fields: []*Field{field},
+	}
+
+	err = cma.ContentTypes.Upsert(spaceID, ct)
+	assert.Nil(err)
+
+	fields := upserted["fields"].([]interface{})
+	validations := fields[0].(map[string]interface{})["validations"].([]interface{})
+	assert.Equal(3, len(validations))
+	assert.Equal("rich-text only allows bold and italic", validations[1].(map[string]interface{})["message"])
+
+	nodes := validations[2].(map[string]interface{})["nodes"].(map[string]interface{})
+	embeds := nodes[RichTextNodeEmbeddedEntryBlock].([]interface{})
+	assert.Equal([]interface{}{"cat"}, embeds[0].(map[string]interface{})["linkContentType"])
+	assert.Equal(map[string]interface{}{"max": float64(2)}, embeds[1].(map[string]interface{})["size"])
+	assert.Equal([]interface{}{}, nodes[RichTextNodeEntryHyperlink])
+}
+
+func TestValidateRichText(t *testing.T) {
+	assert := assert.New(t)
+
+	field := richTextFieldFromTestData(t)
+
+	valid := NewRichTextDocument(
+		NewRichTextNode(RichTextNodeHeading2, NewRichTextText("Cats", RichTextMarkBold)),
+		NewRichTextNode(RichTextNodeUnorderedList,
+			NewRichTextNode(RichTextNodeListItem,
+				NewRichTextNode(RichTextNodeParagraph, NewRichTextText("Nyan", RichTextMarkItalic)),
+			),
+		),
+		NewRichTextEmbed(RichTextNodeEmbeddedEntryBlock, NewLink("Entry", "nyancat")),
+	)
+	assert.Equal([]RichTextValidationError{}, ValidateRichText(valid, field.Validations))
+
+	invalid := NewRichTextDocument(
+		NewRichTextNode(RichTextNodeHeading1, NewRichTextText("Cats", RichTextMarkUnderline)),
+		NewRichTextEmbed(RichTextNodeEmbeddedEntryBlock, NewLink("Entry", "nyancat")),
+		NewRichTextEmbed(RichTextNodeEmbeddedEntryBlock, NewLink("Entry", "happycat")),
+		NewRichTextEmbed(RichTextNodeEmbeddedEntryBlock, NewLink("Entry", "grumpycat")),
+	)
+
+	errs := ValidateRichText(invalid, field.Validations)
+	assert.Equal(3, len(errs))
+
+	assert.Equal([]interface{}{"content", 0}, errs[0].Path)
+	assert.Equal(RichTextNodeHeading1, errs[0].NodeType)
+	assert.Equal("enabledNodeTypes", errs[0].Validation)
+
+	assert.Equal([]interface{}{"content", 0, "content", 0}, errs[1].Path)
+	assert.Equal("enabledMarks", errs[1].Validation)
+	assert.Equal("rich-text only allows bold and italic", errs[1].Message)
+
+	assert.Equal(RichTextNodeEmbeddedEntryBlock, errs[2].NodeType)
+	assert.Equal("nodes", errs[2].Validation)
+	assert.Equal("rich text embedded-entry-block at [] fails nodes validation: rich-text embeds at most two cats", errs[2].Error())
+
+	// without validations anything goes
+	assert.Equal([]RichTextValidationError{}, ValidateRichText(invalid, nil))
+
+	// default messages
+	errs = ValidateRichText(invalid, []FieldValidation{&FieldValidationEnabledMarks{Marks: []string{}}})
+	assert.Equal(1, len(errs))
+	assert.Equal("mark underline is not enabled", errs[0].Message)
+
+	errs = ValidateRichText(NewRichTextNode(RichTextNodeParagraph), nil)
+	assert.Equal(1, len(errs))
+	assert.Equal("nodeType", errs[0].Validation)
+}
+
+func TestValidateRichTextZeroSize(t *testing.T) {
+	assert := assert.New(t)
+
+	validations := []FieldValidation{FieldValidationNodes{Nodes: map[string][]FieldValidation{
+		RichTextNodeEmbeddedEntryBlock: {FieldValidationSize{Size: NewMax(0)}},
+	}}}
+
+	doc := NewRichTextDocument(NewRichTextNode(RichTextNodeParagraph, NewRichTextText("Cats")))
+	assert.Equal([]RichTextValidationError{}, ValidateRichText(doc, validations))
+
+	doc.Content = append(doc.Content, NewRichTextEmbed(RichTextNodeEmbeddedEntryBlock, NewLink("Entry", "nyancat")))
+	errs := ValidateRichText(doc, validations)
+	assert.Equal(1, len(errs))
+	assert.Equal("1 embedded-entry-block nodes are not at most 0", errs[0].Message)
+}
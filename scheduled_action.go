@@ -0,0 +1,256 @@
+package contentful
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// ScheduledActionsService service
+type ScheduledActionsService service
+
+const (
+	// ScheduledActionPublish publishes the entity at the scheduled time
+	ScheduledActionPublish = "publish"
+
+	// ScheduledActionUnpublish unpublishes the entity at the scheduled time
+	ScheduledActionUnpublish = "unpublish"
+)
+
+const (
+	// ScheduledActionStatusScheduled scheduled action is waiting to be executed
+	ScheduledActionStatusScheduled = "scheduled"
+
+	// ScheduledActionStatusSucceeded scheduled action was executed successfully
+	ScheduledActionStatusSucceeded = "succeeded"
+
+	// ScheduledActionStatusFailed scheduled action failed to execute
+	ScheduledActionStatusFailed = "failed"
+
+	// ScheduledActionStatusCanceled scheduled action was canceled
+	ScheduledActionStatusCanceled = "canceled"
+)
+
+// ScheduledAction model
+type ScheduledAction struct {
+	Sys          *Sys          `json:"sys,omitempty"`
+	Entity       *Link         `json:"entity"`
+	Environment  *Link         `json:"environment"`
+	ScheduledFor *ScheduledFor `json:"scheduledFor"`
+	Action       string        `json:"action"`
+}
+
+// ScheduledFor model holds the execution time of a scheduled action
+// and the IANA timezone it is displayed in.
+type ScheduledFor struct {
+	DateTime time.Time
+	Timezone string
+}
+
+// ScheduledActionFilter narrows down a scheduled actions collection
+type ScheduledActionFilter struct {
+	EntityID string
+	Status   []string
+}
+
+// NewScheduledFor returns the execution time `t` expressed in the given IANA timezone.
+// An empty timezone keeps the location of `t`.
+func NewScheduledFor(t time.Time, timezone string) (*ScheduledFor, error) {
+	if timezone == "" {
+		return &ScheduledFor{DateTime: t}, nil
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ScheduledFor{
+		DateTime: t.In(loc),
+		Timezone: timezone,
+	}, nil
+}
+
+// MarshalJSON for custom json marshaling
+func (s *ScheduledFor) MarshalJSON() ([]byte, error) {
+	dateTime := s.DateTime
+
+	if s.Timezone != "" {
+		loc, err := time.LoadLocation(s.Timezone)
+		if err != nil {
+			return nil, err
+		}
+
+		dateTime = dateTime.In(loc)
+	}
+
+	return json.Marshal(&struct {
+		DateTime string `json:"datetime"`
+		Timezone string `json:"timezone,omitempty"`
+	}{
+		DateTime: dateTime.Format(time.RFC3339),
+		Timezone: s.Timezone,
+	})
+}
+
+// UnmarshalJSON for custom json unmarshaling
+func (s *ScheduledFor) UnmarshalJSON(data []byte) error {
+	var payload struct {
+		DateTime string `json:"datetime"`
+		Timezone string `json:"timezone"`
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	dateTime, err := time.Parse(time.RFC3339, payload.DateTime)
+	if err != nil {
+		return err
+	}
+
+	// keep the parsed offset when the timezone database is not available
+	if payload.Timezone != "" {
+		if loc, err := time.LoadLocation(payload.Timezone); err == nil {
+			dateTime = dateTime.In(loc)
+		}
+	}
+
+	s.DateTime = dateTime
+	s.Timezone = payload.Timezone
+
+	return nil
+}
+
+// NewScheduledAction returns a scheduled action of the given kind for an entry, asset or release link
+func NewScheduledAction(action string, entity *Link, t time.Time, timezone string) (*ScheduledAction, error) {
+	scheduledFor, err := NewScheduledFor(t, timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ScheduledAction{
+		Entity:       entity,
+		ScheduledFor: scheduledFor,
+		Action:       action,
+	}, nil
+}
+
+// GetVersion returns entity version
+func (action *ScheduledAction) GetVersion() int {
+	version := 1
+	if action.Sys != nil {
+		version = action.Sys.Version
+	}
+
+	return version
+}
+
+// List returns the scheduled actions collection of the client's environment
+func (service *ScheduledActionsService) List(spaceID string, filter *ScheduledActionFilter) *Collection {
+	path := fmt.Sprintf("/spaces/%s/scheduled_actions", spaceID)
+
+	req, err := service.c.newRequest(http.MethodGet, path, nil, nil)
+	if err != nil {
+		return &Collection{}
+	}
+
+	col := NewCollection(&CollectionOptions{})
+	col.Equal("environment.sys.id", service.c.Environment)
+
+	if filter != nil {
+		if filter.EntityID != "" {
+			col.Equal("entity.sys.id", filter.EntityID)
+		}
+
+		if len(filter.Status) == 1 {
+			col.Equal("sys.status", filter.Status[0])
+		} else if len(filter.Status) > 1 {
+			col.In("sys.status", filter.Status)
+		}
+	}
+
+	col.c = service.c
+	col.req = req
+
+	return col
+}
+
+// Get returns a single scheduled action
+func (service *ScheduledActionsService) Get(spaceID, scheduledActionID string) (*ScheduledAction, error) {
+	path := fmt.Sprintf("/spaces/%s/scheduled_actions/%s", spaceID, scheduledActionID)
+	query := url.Values{}
+	query.Set("environment.sys.id", service.c.Environment)
+
+	req, err := service.c.newRequest(http.MethodGet, path, query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var action ScheduledAction
+	if err := service.c.do(req, &action); err != nil {
+		return nil, err
+	}
+
+	return &action, nil
+}
+
+// Upsert updates or creates a new scheduled action
+func (service *ScheduledActionsService) Upsert(spaceID string, action *ScheduledAction) error {
+	if action.Environment == nil {
+		action.Environment = NewLink("Environment", service.c.Environment)
+	}
+
+	bytesArray, err := json.Marshal(&struct {
+		Entity       *Link         `json:"entity"`
+		Environment  *Link         `json:"environment"`
+		ScheduledFor *ScheduledFor `json:"scheduledFor"`
+		Action       string        `json:"action"`
+	}{
+		Entity:       action.Entity,
+		Environment:  action.Environment,
+		ScheduledFor: action.ScheduledFor,
+		Action:       action.Action,
+	})
+	if err != nil {
+		return err
+	}
+
+	var path string
+	var method string
+
+	if action.Sys != nil && action.Sys.CreatedAt != "" {
+		path = fmt.Sprintf("/spaces/%s/scheduled_actions/%s", spaceID, action.Sys.ID)
+		method = http.MethodPut
+	} else {
+		path = fmt.Sprintf("/spaces/%s/scheduled_actions", spaceID)
+		method = http.MethodPost
+	}
+
+	req, err := service.c.newRequest(method, path, nil, bytes.NewReader(bytesArray))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("X-Contentful-Version", strconv.Itoa(action.GetVersion()))
+
+	return service.c.do(req, action)
+}
+
+// Cancel the scheduled action
+func (service *ScheduledActionsService) Cancel(spaceID string, action *ScheduledAction) error {
+	path := fmt.Sprintf("/spaces/%s/scheduled_actions/%s", spaceID, action.Sys.ID)
+	query := url.Values{}
+	query.Set("environment.sys.id", service.c.Environment)
+
+	req, err := service.c.newRequest(http.MethodDelete, path, query, nil)
+	if err != nil {
+		return err
+	}
+
+	return service.c.do(req, action)
+}
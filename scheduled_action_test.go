@@ -0,0 +1,184 @@
+package contentful
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduledForMarshalJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	at := time.Date(2021, time.March, 31, 15, 0, 0, 0, time.UTC)
+	scheduledFor, err := NewScheduledFor(at, "Asia/Tokyo")
+	assert.Nil(err)
+	assert.True(at.Equal(scheduledFor.DateTime))
+
+	data, err := json.Marshal(scheduledFor)
+	assert.Nil(err)
+	assert.JSONEq(`{"datetime":"2021-04-01T00:00:00+09:00","timezone":"Asia/Tokyo"}`, string(data))
+
+	_, err = NewScheduledFor(at, "Mars/Olympus_Mons")
+	assert.NotNil(err)
+}
+
+func TestScheduledForUnmarshalJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	var scheduledFor ScheduledFor
+	err := json.Unmarshal([]byte(`{"datetime":"2021-04-01T00:00:00.000+09:00","timezone":"Asia/Tokyo"}`), &scheduledFor)
+	assert.Nil(err)
+	assert.Equal("Asia/Tokyo", scheduledFor.Timezone)
+	assert.Equal("Asia/Tokyo", scheduledFor.DateTime.Location().String())
+	assert.True(time.Date(2021, time.March, 31, 15, 0, 0, 0, time.UTC).Equal(scheduledFor.DateTime))
+
+	err = json.Unmarshal([]byte(`{"datetime":"not a date"}`), &scheduledFor)
+	assert.NotNil(err)
+}
+
+func TestScheduledActionSaveForCreate(t *testing.T) {
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("POST", r.Method)
+		assert.Equal("/spaces/"+spaceID+"/scheduled_actions", r.RequestURI)
+		checkHeaders(r, assert)
+
+		var payload map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&payload)
+		assert.Nil(err)
+		assert.Equal("publish", payload["action"])
+		assert.Equal("nyancat", payload["entity"].(map[string]interface{})["sys"].(map[string]interface{})["id"])
+		assert.Equal("master", payload["environment"].(map[string]interface{})["sys"].(map[string]interface{})["id"])
+		assert.Equal("Environment", payload["environment"].(map[string]interface{})["sys"].(map[string]interface{})["linkType"])
+
+		scheduledFor := payload["scheduledFor"].(map[string]interface{})
+		assert.Equal("2021-04-01T00:00:00+09:00", scheduledFor["datetime"])
+		assert.Equal("Asia/Tokyo", scheduledFor["timezone"])
+
+		w.WriteHeader(201)
+		fmt.Fprintln(w, readTestData("scheduled_action.json"))
+	})
+
+	// test server
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	assert.Nil(err)
+
+	action, err := NewScheduledAction(ScheduledActionPublish, NewLink("Entry", "nyancat"), time.Date(2021, time.April, 1, 0, 0, 0, 0, tokyo), "Asia/Tokyo")
+	assert.Nil(err)
+
+	err = cma.ScheduledActions.Upsert(spaceID, action)
+	assert.Nil(err)
+	assert.Equal("scheduled-1", action.Sys.ID)
+	assert.Equal(ScheduledActionStatusScheduled, action.Sys.Status)
+}
+
+func TestScheduledActionSaveForUpdate(t *testing.T) {
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("PUT", r.Method)
+		assert.Equal("/spaces/"+spaceID+"/scheduled_actions/scheduled-1", r.RequestURI)
+		assert.Equal("1", r.Header.Get("X-Contentful-Version"))
+
+		var payload map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&payload)
+		assert.Nil(err)
+		assert.Nil(payload["sys"])
+
+		scheduledFor := payload["scheduledFor"].(map[string]interface{})
+		assert.Equal("2021-04-02T00:00:00+09:00", scheduledFor["datetime"])
+
+		fmt.Fprintln(w, readTestData("scheduled_action.json"))
+	})
+
+	// test server
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	var action ScheduledAction
+	err := json.Unmarshal([]byte(readTestData("scheduled_action.json")), &action)
+	assert.Nil(err)
+
+	action.ScheduledFor.DateTime = action.ScheduledFor.DateTime.AddDate(0, 0, 1)
+	err = cma.ScheduledActions.Upsert(spaceID, &action)
+	assert.Nil(err)
+}
+
+func TestScheduledActionsServiceList(t *testing.T) {
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("GET", r.Method)
+		assert.Equal("/spaces/"+spaceID+"/scheduled_actions", r.URL.Path)
+
+		query := r.URL.Query()
+		assert.Equal("master", query.Get("environment.sys.id"))
+		assert.Equal("nyancat", query.Get("entity.sys.id"))
+		assert.Equal("scheduled,failed", query.Get("sys.status[in]"))
+
+		fmt.Fprintln(w, readTestData("scheduled_actions.json"))
+	})
+
+	// test server
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	col, err := cma.ScheduledActions.List(spaceID, &ScheduledActionFilter{
+		EntityID: "nyancat",
+		Status:   []string{ScheduledActionStatusScheduled, ScheduledActionStatusFailed},
+	}).Next()
+	assert.Nil(err)
+
+	actions := col.ToScheduledAction()
+	assert.Equal(2, len(actions))
+	assert.Equal("scheduled-1", actions[0].Sys.ID)
+	assert.Equal("Asia/Tokyo", actions[0].ScheduledFor.Timezone)
+	assert.Equal(ScheduledActionUnpublish, actions[1].Action)
+	assert.Equal("", actions[1].ScheduledFor.Timezone)
+}
+
+func TestScheduledActionsServiceCancel(t *testing.T) {
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("DELETE", r.Method)
+		assert.Equal("/spaces/"+spaceID+"/scheduled_actions/scheduled-1", r.URL.Path)
+		assert.Equal("master", r.URL.Query().Get("environment.sys.id"))
+
+		fmt.Fprintln(w, `{"sys":{"type":"ScheduledAction","id":"scheduled-1","version":2,"status":"canceled"},"action":"publish"}`)
+	})
+
+	// test server
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	action := &ScheduledAction{Sys: &Sys{ID: "scheduled-1", Version: 1}}
+	err := cma.ScheduledActions.Cancel(spaceID, action)
+	assert.Nil(err)
+	assert.Equal(ScheduledActionStatusCanceled, action.Sys.Status)
+}
@@ -0,0 +1,127 @@
+package contentful
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+const (
+	// SnapshotTypePublish snapshot taken when the entity was published
+	SnapshotTypePublish = "publish"
+)
+
+// EntrySnapshot model
+type EntrySnapshot struct {
+	Sys      *Sys   `json:"sys"`
+	Snapshot *Entry `json:"snapshot"`
+}
+
+// ContentTypeSnapshot model
+type ContentTypeSnapshot struct {
+	Sys      *Sys         `json:"sys"`
+	Snapshot *ContentType `json:"snapshot"`
+}
+
+// Diff returns the changes between the snapshot and the given entry
+func (snapshot *EntrySnapshot) Diff(entry *Entry) *EntryDiff {
+	return DiffEntries(snapshot.Snapshot, entry)
+}
+
+// ListSnapshots returns the snapshots collection of the given entry
+func (service *EntriesService) ListSnapshots(spaceID, entryID string) *Collection {
+	path := fmt.Sprintf("/spaces/%s/environments/%s/entries/%s/snapshots", spaceID, service.c.Environment, entryID)
+
+	req, err := service.c.newRequest(http.MethodGet, path, nil, nil)
+	if err != nil {
+		return &Collection{}
+	}
+
+	col := NewCollection(&CollectionOptions{})
+	col.c = service.c
+	col.req = req
+
+	return col
+}
+
+// GetSnapshot returns a single snapshot of the given entry
+func (service *EntriesService) GetSnapshot(spaceID, entryID, snapshotID string) (*EntrySnapshot, error) {
+	path := fmt.Sprintf("/spaces/%s/environments/%s/entries/%s/snapshots/%s", spaceID, service.c.Environment, entryID, snapshotID)
+
+	req, err := service.c.newRequest(http.MethodGet, path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot EntrySnapshot
+	if err := service.c.do(req, &snapshot); err != nil {
+		return nil, err
+	}
+
+	return &snapshot, nil
+}
+
+// Rollback replaces the fields of the entry with the fields of the snapshot
+// and saves them as the new draft of the entry.
+func (service *EntriesService) Rollback(spaceID string, entry *Entry, snapshot *EntrySnapshot) error {
+	if snapshot.Snapshot == nil {
+		return fmt.Errorf("snapshot does not contain an entry")
+	}
+
+	fields, err := copyFields(snapshot.Snapshot.Fields)
+	if err != nil {
+		return err
+	}
+
+	entry.Fields = fields
+
+	return service.Upsert(spaceID, entry)
+}
+
+// ListSnapshots returns the snapshots collection of the given content type
+func (service *ContentTypesService) ListSnapshots(spaceID, contentTypeID string) *Collection {
+	path := fmt.Sprintf("/spaces/%s/environments/%s/content_types/%s/snapshots", spaceID, service.c.Environment, contentTypeID)
+
+	req, err := service.c.newRequest(http.MethodGet, path, nil, nil)
+	if err != nil {
+		return &Collection{}
+	}
+
+	col := NewCollection(&CollectionOptions{})
+	col.c = service.c
+	col.req = req
+
+	return col
+}
+
+// GetSnapshot returns a single snapshot of the given content type
+func (service *ContentTypesService) GetSnapshot(spaceID, contentTypeID, snapshotID string) (*ContentTypeSnapshot, error) {
+	path := fmt.Sprintf("/spaces/%s/environments/%s/content_types/%s/snapshots/%s", spaceID, service.c.Environment, contentTypeID, snapshotID)
+
+	req, err := service.c.newRequest(http.MethodGet, path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot ContentTypeSnapshot
+	if err := service.c.do(req, &snapshot); err != nil {
+		return nil, err
+	}
+
+	return &snapshot, nil
+}
+
+// copyFields returns a deep copy of entry fields in their json representation
+func copyFields(fields map[string]interface{}) (map[string]interface{}, error) {
+	byteArray, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	copied := map[string]interface{}{}
+	if err := json.Unmarshal(byteArray, &copied); err != nil {
+		return nil, err
+	}
+
+	return copied, nil
+}
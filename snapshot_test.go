@@ -0,0 +1,146 @@
+package contentful
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntriesServiceListSnapshots(t *testing.T) {
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("GET", r.Method)
+		assert.Equal("/spaces/"+spaceID+"/environments/master/entries/foocat/snapshots", r.URL.Path)
+
+		fmt.Fprintln(w, readTestData("entry_snapshots.json"))
+	})
+
+	// test server
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	col, err := cma.Entries.ListSnapshots(spaceID, "foocat").Next()
+	assert.Nil(err)
+
+	snapshots := col.ToEntrySnapshot()
+	assert.Equal(2, len(snapshots))
+	assert.Equal("snapshot-2", snapshots[0].Sys.ID)
+	assert.Equal(SnapshotTypePublish, snapshots[0].Sys.SnapshotType)
+	assert.Equal("Entry", snapshots[0].Sys.SnapshotEntityType)
+	assert.Equal(3, snapshots[1].Snapshot.Sys.Version)
+}
+
+func TestEntriesServiceGetSnapshot(t *testing.T) {
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("GET", r.Method)
+		assert.Equal("/spaces/"+spaceID+"/environments/master/entries/foocat/snapshots/snapshot-1", r.URL.Path)
+
+		fmt.Fprintln(w, readTestData("entry_snapshot.json"))
+	})
+
+	// test server
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	snapshot, err := cma.Entries.GetSnapshot(spaceID, "foocat", "snapshot-1")
+	assert.Nil(err)
+	assert.Equal("snapshot-1", snapshot.Sys.ID)
+	assert.Equal("foocat", snapshot.Snapshot.Sys.ID)
+	assert.Equal("Nyan Cat", snapshot.Snapshot.Fields["name"].(map[string]interface{})["en-US"])
+}
+
+func TestEntriesServiceRollback(t *testing.T) {
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("PUT", r.Method)
+		assert.Equal("/spaces/"+spaceID+"/environments/master/entries/foocat", r.RequestURI)
+		assert.Equal("5", r.Header.Get("X-Contentful-Version"))
+
+		var payload map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&payload)
+		assert.Nil(err)
+
+		fields := payload["fields"].(map[string]interface{})
+		assert.Equal(map[string]interface{}{"en-US": []interface{}{"rainbows"}}, fields["likes"])
+		assert.Nil(fields["bestFriend"])
+
+		fmt.Fprintln(w, readTestData("entry_3.json"))
+	})
+
+	// test server
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	var entry Entry
+	err := json.Unmarshal([]byte(readTestData("entry_3.json")), &entry)
+	assert.Nil(err)
+	entry.Sys.Version = 5
+
+	var snapshot EntrySnapshot
+	err = json.Unmarshal([]byte(readTestData("entry_snapshot.json")), &snapshot)
+	assert.Nil(err)
+
+	err = cma.Entries.Rollback(spaceID, &entry, &snapshot)
+	assert.Nil(err)
+
+	// rolling back must not share state with the snapshot
+	entry.Fields["name"] = map[string]interface{}{"en-US": "changed"}
+	assert.Equal("Nyan Cat", snapshot.Snapshot.Fields["name"].(map[string]interface{})["en-US"])
+}
+
+func TestContentTypesServiceSnapshots(t *testing.T) {
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("GET", r.Method)
+
+		switch r.URL.Path {
+		case "/spaces/" + spaceID + "/environments/master/content_types/63Vgs0BFK0USe4i2mQUGK6/snapshots":
+			fmt.Fprintln(w, `{"sys":{"type":"Array"},"total":1,"items":[`+readTestData("content_type_snapshot.json")+`]}`)
+		case "/spaces/" + spaceID + "/environments/master/content_types/63Vgs0BFK0USe4i2mQUGK6/snapshots/snapshot-1":
+			fmt.Fprintln(w, readTestData("content_type_snapshot.json"))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+
+	// test server
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	col, err := cma.ContentTypes.ListSnapshots(spaceID, "63Vgs0BFK0USe4i2mQUGK6").Next()
+	assert.Nil(err)
+	snapshots := col.ToContentTypeSnapshot()
+	assert.Equal(1, len(snapshots))
+	assert.Equal("ContentType", snapshots[0].Sys.SnapshotEntityType)
+
+	snapshot, err := cma.ContentTypes.GetSnapshot(spaceID, "63Vgs0BFK0USe4i2mQUGK6", "snapshot-1")
+	assert.Nil(err)
+	assert.Equal("ct-name", snapshot.Snapshot.Name)
+	assert.Equal(1, len(snapshot.Snapshot.Fields))
+	assert.Equal("field1", snapshot.Snapshot.Fields[0].ID)
+}
@@ -0,0 +1,189 @@
+package contentful
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// TagsService service
+type TagsService service
+
+const (
+	// TagVisibilityPrivate tag is only visible through the CMA
+	TagVisibilityPrivate = "private"
+
+	// TagVisibilityPublic tag is visible through the CMA, CDA and CPA
+	TagVisibilityPublic = "public"
+)
+
+// Tag model
+type Tag struct {
+	Sys  *Sys   `json:"sys"`
+	Name string `json:"name"`
+}
+
+// Metadata model holds the tags of an entry or asset
+type Metadata struct {
+	Tags []*Link `json:"tags"`
+}
+
+// NewTag returns a tag with the given id, name and visibility
+func NewTag(id, name, visibility string) *Tag {
+	return &Tag{
+		Sys: &Sys{
+			ID:         id,
+			Visibility: visibility,
+		},
+		Name: name,
+	}
+}
+
+// GetVersion returns entity version
+func (tag *Tag) GetVersion() int {
+	version := 1
+	if tag.Sys != nil {
+		version = tag.Sys.Version
+	}
+
+	return version
+}
+
+// AddTag links the tag with the given id
+func (metadata *Metadata) AddTag(tagID string) {
+	if metadata.HasTag(tagID) {
+		return
+	}
+
+	metadata.Tags = append(metadata.Tags, NewLink("Tag", tagID))
+}
+
+// RemoveTag unlinks the tag with the given id
+func (metadata *Metadata) RemoveTag(tagID string) {
+	tags := []*Link{}
+
+	for _, tag := range metadata.Tags {
+		if tag.Sys != nil && tag.Sys.ID == tagID {
+			continue
+		}
+
+		tags = append(tags, tag)
+	}
+
+	metadata.Tags = tags
+}
+
+// HasTag reports whether the tag with the given id is linked
+func (metadata *Metadata) HasTag(tagID string) bool {
+	for _, tag := range metadata.Tags {
+		if tag.Sys != nil && tag.Sys.ID == tagID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TagIDs returns the ids of the linked tags
+func (metadata *Metadata) TagIDs() []string {
+	ids := []string{}
+
+	for _, tag := range metadata.Tags {
+		if tag.Sys != nil {
+			ids = append(ids, tag.Sys.ID)
+		}
+	}
+
+	return ids
+}
+
+// List returns tags collection
+func (service *TagsService) List(spaceID string) *Collection {
+	path := fmt.Sprintf("/spaces/%s/environments/%s/tags", spaceID, service.c.Environment)
+
+	req, err := service.c.newRequest(http.MethodGet, path, nil, nil)
+	if err != nil {
+		return &Collection{}
+	}
+
+	col := NewCollection(&CollectionOptions{})
+	col.c = service.c
+	col.req = req
+
+	return col
+}
+
+// Get returns a single tag
+func (service *TagsService) Get(spaceID, tagID string) (*Tag, error) {
+	path := fmt.Sprintf("/spaces/%s/environments/%s/tags/%s", spaceID, service.c.Environment, tagID)
+
+	req, err := service.c.newRequest(http.MethodGet, path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var tag Tag
+	if err := service.c.do(req, &tag); err != nil {
+		return nil, err
+	}
+
+	return &tag, nil
+}
+
+// Upsert creates a new tag or renames an existing one.
+// Tag ids are chosen by the client, the visibility can not be changed after creation.
+func (service *TagsService) Upsert(spaceID string, tag *Tag) error {
+	if tag.Sys == nil || tag.Sys.ID == "" {
+		return fmt.Errorf("creating/updating a tag requires a tag id")
+	}
+
+	visibility := tag.Sys.Visibility
+	if visibility == "" {
+		visibility = TagVisibilityPrivate
+	}
+
+	bytesArray, err := json.Marshal(&struct {
+		Name string `json:"name"`
+		Sys  *Sys   `json:"sys"`
+	}{
+		Name: tag.Name,
+		Sys: &Sys{
+			ID:         tag.Sys.ID,
+			Type:       "Tag",
+			Visibility: visibility,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("/spaces/%s/environments/%s/tags/%s", spaceID, service.c.Environment, tag.Sys.ID)
+
+	req, err := service.c.newRequest(http.MethodPut, path, nil, bytes.NewReader(bytesArray))
+	if err != nil {
+		return err
+	}
+
+	if tag.Sys.CreatedAt != "" {
+		req.Header.Set("X-Contentful-Version", strconv.Itoa(tag.GetVersion()))
+	}
+
+	return service.c.do(req, tag)
+}
+
+// Delete the tag
+func (service *TagsService) Delete(spaceID string, tag *Tag) error {
+	path := fmt.Sprintf("/spaces/%s/environments/%s/tags/%s", spaceID, service.c.Environment, tag.Sys.ID)
+
+	req, err := service.c.newRequest(http.MethodDelete, path, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	version := strconv.Itoa(tag.Sys.Version)
+	req.Header.Set("X-Contentful-Version", version)
+
+	return service.c.do(req, nil)
+}
@@ -0,0 +1,222 @@
+package contentful
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagSaveForCreate(t *testing.T) {
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("PUT", r.Method)
+		assert.Equal("/spaces/"+spaceID+"/environments/master/tags/campaign", r.RequestURI)
+		assert.Equal("", r.Header.Get("X-Contentful-Version"))
+		checkHeaders(r, assert)
+
+		var payload map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&payload)
+		assert.Nil(err)
+		assert.Equal("Campaign", payload["name"])
+
+		sys := payload["sys"].(map[string]interface{})
+		assert.Equal("campaign", sys["id"])
+		assert.Equal("Tag", sys["type"])
+		assert.Equal(TagVisibilityPublic, sys["visibility"])
+
+		w.WriteHeader(201)
+		fmt.Fprintln(w, readTestData("tag.json"))
+	})
+
+	// test server
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	tag := NewTag("campaign", "Campaign", TagVisibilityPublic)
+	err := cma.Tags.Upsert(spaceID, tag)
+	assert.Nil(err)
+	assert.Equal(1, tag.Sys.Version)
+	assert.Equal("2021-03-15T10:00:00.000Z", tag.Sys.CreatedAt)
+
+	err = cma.Tags.Upsert(spaceID, &Tag{Name: "no id"})
+	assert.NotNil(err)
+}
+
+func TestTagSaveForUpdate(t *testing.T) {
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("PUT", r.Method)
+		assert.Equal("/spaces/"+spaceID+"/environments/master/tags/campaign", r.RequestURI)
+		assert.Equal("1", r.Header.Get("X-Contentful-Version"))
+
+		var payload map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&payload)
+		assert.Nil(err)
+		assert.Equal("Spring campaign", payload["name"])
+
+		fmt.Fprintln(w, readTestData("tag.json"))
+	})
+
+	// test server
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	var tag Tag
+	err := json.Unmarshal([]byte(readTestData("tag.json")), &tag)
+	assert.Nil(err)
+
+	tag.Name = "Spring campaign"
+	err = cma.Tags.Upsert(spaceID, &tag)
+	assert.Nil(err)
+}
+
+func TestTagsServiceList(t *testing.T) {
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("GET", r.Method)
+		assert.Equal("/spaces/"+spaceID+"/environments/master/tags", r.URL.Path)
+
+		fmt.Fprintln(w, readTestData("tags.json"))
+	})
+
+	// test server
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	col, err := cma.Tags.List(spaceID).Next()
+	assert.Nil(err)
+
+	tags := col.ToTag()
+	assert.Equal(2, len(tags))
+	assert.Equal("legal", tags[1].Sys.ID)
+	assert.Equal("Legal review", tags[1].Name)
+	assert.Equal(TagVisibilityPrivate, tags[1].Sys.Visibility)
+}
+
+func TestTagsServiceDelete(t *testing.T) {
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("DELETE", r.Method)
+		assert.Equal("/spaces/"+spaceID+"/environments/master/tags/legal", r.RequestURI)
+		assert.Equal("3", r.Header.Get("X-Contentful-Version"))
+		w.WriteHeader(204)
+	})
+
+	// test server
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	err := cma.Tags.Delete(spaceID, &Tag{Sys: &Sys{ID: "legal", Version: 3}})
+	assert.Nil(err)
+}
+
+func TestMetadataTags(t *testing.T) {
+	assert := assert.New(t)
+
+	metadata := &Metadata{}
+	metadata.AddTag("campaign")
+	metadata.AddTag("legal")
+	metadata.AddTag("campaign")
+	assert.Equal([]string{"campaign", "legal"}, metadata.TagIDs())
+	assert.True(metadata.HasTag("legal"))
+	assert.Equal("Tag", metadata.Tags[0].Sys.LinkType)
+
+	metadata.RemoveTag("campaign")
+	assert.Equal([]string{"legal"}, metadata.TagIDs())
+	assert.False(metadata.HasTag("campaign"))
+}
+
+func TestEntryMetadataRoundTrip(t *testing.T) {
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&payload)
+		assert.Nil(err)
+
+		tags := payload["metadata"].(map[string]interface{})["tags"].([]interface{})
+		assert.Equal(1, len(tags))
+		sys := tags[0].(map[string]interface{})["sys"].(map[string]interface{})
+		assert.Equal("Link", sys["type"])
+		assert.Equal("Tag", sys["linkType"])
+		assert.Equal("campaign", sys["id"])
+
+		// echo the payload back as the saved entry
+		payload["sys"] = map[string]interface{}{"id": "foocat", "version": 2}
+		json.NewEncoder(w).Encode(payload)
+	})
+
+	// test server
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	entry := &Entry{
+		Sys: &Sys{
+			ContentType: &ContentType{Sys: &Sys{ID: "cat"}},
+		},
+		Metadata: &Metadata{},
+		Fields: map[string]interface{}{
+			"name": map[string]string{"en-US": "Nyan Cat"},
+		},
+	}
+	entry.Metadata.AddTag("campaign")
+
+	err := cma.Entries.Upsert(spaceID, entry)
+	assert.Nil(err)
+	assert.Equal([]string{"campaign"}, entry.Metadata.TagIDs())
+}
+
+func TestAssetMetadataMarshal(t *testing.T) {
+	assert := assert.New(t)
+
+	asset := &Asset{
+		Sys:      &Sys{ID: "happycat"},
+		Metadata: &Metadata{},
+		Fields: &FileFields{
+			Title: map[string]string{"en-US": "Happy Cat"},
+		},
+	}
+	asset.Metadata.AddTag("campaign")
+
+	data, err := json.Marshal(asset)
+	assert.Nil(err)
+
+	var payload map[string]interface{}
+	err = json.Unmarshal(data, &payload)
+	assert.Nil(err)
+	tags := payload["metadata"].(map[string]interface{})["tags"].([]interface{})
+	assert.Equal("campaign", tags[0].(map[string]interface{})["sys"].(map[string]interface{})["id"])
+
+	var decoded Asset
+	err = json.Unmarshal([]byte(`{"sys":{"id":"happycat"},"metadata":{"tags":[{"sys":{"type":"Link","linkType":"Tag","id":"legal"}}]},"fields":{"title":"Happy Cat","file":{"fileName":"happycat.jpg"}}}`), &decoded)
+	assert.Nil(err)
+	assert.Equal([]string{"legal"}, decoded.Metadata.TagIDs())
+}
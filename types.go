@@ -2,19 +2,42 @@ package contentful
 
 // Sys model
 type Sys struct {
-	ID               string       `json:"id,omitempty"`
-	Type             string       `json:"type,omitempty"`
-	LinkType         string       `json:"linkType,omitempty"`
-	CreatedAt        string       `json:"createdAt,omitempty"`
-	UpdatedAt        string       `json:"updatedAt,omitempty"`
-	UpdatedBy        *Sys         `json:"updatedBy,omitempty"`
-	Version          int          `json:"version,omitempty"`
-	Revision         int          `json:"revision,omitempty"`
-	ContentType      *ContentType `json:"contentType,omitempty"`
-	Space            *Space       `json:"space,omitempty"`
-	FirstPublishedAt string       `json:"firstPublishedAt,omitempty"`
-	PublishedCounter int          `json:"publishedCounter,omitempty"`
-	PublishedAt      string       `json:"publishedAt,omitempty"`
-	PublishedBy      *Sys         `json:"publishedBy,omitempty"`
-	PublishedVersion int          `json:"publishedVersion,omitempty"`
+	ID                 string       `json:"id,omitempty"`
+	Type               string       `json:"type,omitempty"`
+	LinkType           string       `json:"linkType,omitempty"`
+	CreatedAt          string       `json:"createdAt,omitempty"`
+	UpdatedAt          string       `json:"updatedAt,omitempty"`
+	UpdatedBy          *Sys         `json:"updatedBy,omitempty"`
+	Version            int          `json:"version,omitempty"`
+	Revision           int          `json:"revision,omitempty"`
+	ContentType        *ContentType `json:"contentType,omitempty"`
+	Space              *Space       `json:"space,omitempty"`
+	FirstPublishedAt   string       `json:"firstPublishedAt,omitempty"`
+	PublishedCounter   int          `json:"publishedCounter,omitempty"`
+	PublishedAt        string       `json:"publishedAt,omitempty"`
+	PublishedBy        *Sys         `json:"publishedBy,omitempty"`
+	PublishedVersion   int          `json:"publishedVersion,omitempty"`
+	Status             string       `json:"status,omitempty"`
+	Release            *Link        `json:"release,omitempty"`
+	SnapshotType       string       `json:"snapshotType,omitempty"`
+	SnapshotEntityType string       `json:"snapshotEntityType,omitempty"`
+	Visibility         string       `json:"visibility,omitempty"`
+	ExpiresAt          string       `json:"expiresAt,omitempty"`
+	Locale             string       `json:"locale,omitempty"`
+}
+
+// Link model
+type Link struct {
+	Sys *Sys `json:"sys"`
+}
+
+// NewLink returns a link to the entity with the given type and id
+func NewLink(linkType, id string) *Link {
+	return &Link{
+		Sys: &Sys{
+			ID:       id,
+			Type:     "Link",
+			LinkType: linkType,
+		},
+	}
 }
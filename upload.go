@@ -0,0 +1,196 @@
+package contentful
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// UploadsService service
+//
+// The Upload API takes each binary in a single request, it has no protocol for
+// sending a file in parts. Large files such as videos are streamed from their
+// reader instead, with chunked transfer encoding when their size is unknown.
+type UploadsService service
+
+// UploadMaxSize is the largest binary in bytes accepted by the Upload API
+const UploadMaxSize = 1000 * 1024 * 1024
+
+// Upload model
+type Upload struct {
+	Sys *Sys `json:"sys"`
+}
+
+// UploadProgressFunc is called while an upload is streamed with the number of bytes sent so far.
+// total is 0 when the size of the upload is unknown.
+type UploadProgressFunc func(sent, total int64)
+
+// UploadOptions model
+type UploadOptions struct {
+	// Size of the upload in bytes, optional for files and readers of a known length
+	// such as bytes.Reader. When the size is unknown the upload is streamed with
+	// chunked transfer encoding.
+	Size int64
+
+	// Progress callback, optional
+	Progress UploadProgressFunc
+}
+
+type progressReader struct {
+	r        io.Reader
+	sent     int64
+	total    int64
+	progress UploadProgressFunc
+}
+
+func (pr *progressReader) Read(p []byte) (int, error) {
+	n, err := pr.r.Read(p)
+	if n > 0 {
+		pr.sent += int64(n)
+		pr.progress(pr.sent, pr.total)
+	}
+
+	return n, err
+}
+
+// Link returns the link to the upload used by asset files
+func (upload *Upload) Link() *Link {
+	return NewLink("Upload", upload.Sys.ID)
+}
+
+// NewUploadFile returns an asset file which refers to the given upload
+func NewUploadFile(upload *Upload, fileName, contentType string) *File {
+	return &File{
+		Name:        fileName,
+		ContentType: contentType,
+		UploadFrom:  upload.Link(),
+	}
+}
+
+// readerSize returns the number of bytes left in readers of a known length
+func readerSize(r io.Reader) int64 {
+	switch r := r.(type) {
+	case *bytes.Reader:
+		return int64(r.Len())
+	case *bytes.Buffer:
+		return int64(r.Len())
+	case *strings.Reader:
+		return int64(r.Len())
+	case *os.File:
+		info, err := r.Stat()
+		if err != nil || !info.Mode().IsRegular() {
+			return 0
+		}
+
+		offset, err := r.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return 0
+		}
+
+		return info.Size() - offset
+	}
+
+	return 0
+}
+
+func (c *Client) newUploadRequest(method, path string, body io.Reader) (*http.Request, error) {
+	u, err := url.Parse(c.UploadBaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	u.Path = path
+
+	req, err := http.NewRequest(method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	// set headers
+	for key, value := range c.Headers {
+		req.Header.Set(key, value)
+	}
+
+	return req, nil
+}
+
+// Create streams the content of `r` to the upload API.
+// The reader is consumed as it is sent, large files are never held in memory.
+func (service *UploadsService) Create(spaceID string, r io.Reader, options *UploadOptions) (*Upload, error) {
+	path := fmt.Sprintf("/spaces/%s/uploads", spaceID)
+
+	if options == nil {
+		options = &UploadOptions{}
+	}
+
+	// the length is read before the reader is wrapped for the progress callback
+	size := options.Size
+	if size <= 0 {
+		size = readerSize(r)
+	}
+
+	if size > UploadMaxSize {
+		return nil, fmt.Errorf("upload of %d bytes exceeds the maximum of %d bytes", size, UploadMaxSize)
+	}
+
+	body := r
+	if options.Progress != nil {
+		body = &progressReader{
+			r:        r,
+			total:    size,
+			progress: options.Progress,
+		}
+	}
+
+	req, err := service.c.newUploadRequest(http.MethodPost, path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	// a zero content length with a body makes the transport use chunked encoding
+	if size > 0 {
+		req.ContentLength = size
+	}
+
+	var upload Upload
+	if err := service.c.do(req, &upload); err != nil {
+		return nil, err
+	}
+
+	return &upload, nil
+}
+
+// Get returns a single upload
+func (service *UploadsService) Get(spaceID, uploadID string) (*Upload, error) {
+	path := fmt.Sprintf("/spaces/%s/uploads/%s", spaceID, uploadID)
+
+	req, err := service.c.newUploadRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var upload Upload
+	if err := service.c.do(req, &upload); err != nil {
+		return nil, err
+	}
+
+	return &upload, nil
+}
+
+// Delete the upload
+func (service *UploadsService) Delete(spaceID string, upload *Upload) error {
+	path := fmt.Sprintf("/spaces/%s/uploads/%s", spaceID, upload.Sys.ID)
+
+	req, err := service.c.newUploadRequest(http.MethodDelete, path, nil)
+	if err != nil {
+		return err
+	}
+
+	return service.c.do(req, nil)
+}
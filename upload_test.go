@@ -0,0 +1,197 @@
+package contentful
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUploadsServiceCreate(t *testing.T) {
+	assert := assert.New(t)
+	content := bytes.Repeat([]byte("nyan"), 64*1024)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("POST", r.Method)
+		assert.Equal("/spaces/"+spaceID+"/uploads", r.RequestURI)
+		assert.Equal("application/octet-stream", r.Header.Get("Content-Type"))
+		assert.Equal("Bearer "+CMAToken, r.Header.Get("Authorization"))
+		assert.Equal(int64(len(content)), r.ContentLength)
+
+		body, err := ioutil.ReadAll(r.Body)
+		assert.Nil(err)
+		assert.Equal(content, body)
+
+		w.WriteHeader(201)
+		fmt.Fprintln(w, readTestData("upload.json"))
+	})
+
+	// test server
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.UploadBaseURL = server.URL
+
+	var sent, total int64
+	calls := 0
+	upload, err := cma.Uploads.Create(spaceID, bytes.NewReader(content), &UploadOptions{
+		Size: int64(len(content)),
+		Progress: func(s, t int64) {
+			calls++
+			sent = s
+			total = t
+		},
+	})
+	assert.Nil(err)
+	assert.Equal("upload-1", upload.Sys.ID)
+	assert.Equal("2021-03-17T00:00:00.000Z", upload.Sys.ExpiresAt)
+	assert.True(calls > 0)
+	assert.Equal(int64(len(content)), sent)
+	assert.Equal(int64(len(content)), total)
+}
+
+func TestUploadsServiceCreateChunked(t *testing.T) {
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal([]string{"chunked"}, r.TransferEncoding)
+
+		body, err := ioutil.ReadAll(r.Body)
+		assert.Nil(err)
+		assert.Equal("streamed content", string(body))
+
+		w.WriteHeader(201)
+		fmt.Fprintln(w, readTestData("upload.json"))
+	})
+
+	// test server
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.UploadBaseURL = server.URL
+
+	// hide the length of the reader so that the size is unknown
+	r := struct{ *strings.Reader }{strings.NewReader("streamed content")}
+	upload, err := cma.Uploads.Create(spaceID, r, nil)
+	assert.Nil(err)
+	assert.Equal("upload-1", upload.Sys.ID)
+}
+
+func TestUploadsServiceCreateKnownLength(t *testing.T) {
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(int64(len("known content")), r.ContentLength)
+		assert.Nil(r.TransferEncoding)
+
+		w.WriteHeader(201)
+		fmt.Fprintln(w, readTestData("upload.json"))
+	})
+
+	// test server
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.UploadBaseURL = server.URL
+
+	// the length of the reader is used without a size
+	upload, err := cma.Uploads.Create(spaceID, strings.NewReader("known content"), &UploadOptions{})
+	assert.Nil(err)
+	assert.Equal("upload-1", upload.Sys.ID)
+
+	// also when the reader is wrapped to report the progress
+	var total int64
+	_, err = cma.Uploads.Create(spaceID, bytes.NewReader([]byte("known content")), &UploadOptions{
+		Progress: func(s, t int64) { total = t },
+	})
+	assert.Nil(err)
+	assert.Equal(int64(len("known content")), total)
+
+	// and of the rest of a file
+	f, err := ioutil.TempFile("", "upload")
+	assert.Nil(err)
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	_, err = f.WriteString("skipped known content")
+	assert.Nil(err)
+	_, err = f.Seek(int64(len("skipped ")), io.SeekStart)
+	assert.Nil(err)
+
+	_, err = cma.Uploads.Create(spaceID, f, nil)
+	assert.Nil(err)
+
+	_, err = cma.Uploads.Create(spaceID, strings.NewReader(""), &UploadOptions{Size: UploadMaxSize + 1})
+	assert.EqualError(err, "upload of 1048576001 bytes exceeds the maximum of 1048576000 bytes")
+}
+
+func TestUploadsServiceGetAndDelete(t *testing.T) {
+	assert := assert.New(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("/spaces/"+spaceID+"/uploads/upload-1", r.RequestURI)
+
+		switch r.Method {
+		case "GET":
+			fmt.Fprintln(w, readTestData("upload.json"))
+		case "DELETE":
+			w.WriteHeader(204)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+
+	// test server
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// cma client
+	cma = NewCMA(CMAToken)
+	cma.UploadBaseURL = server.URL
+
+	upload, err := cma.Uploads.Get(spaceID, "upload-1")
+	assert.Nil(err)
+	assert.Equal("upload-1", upload.Sys.ID)
+
+	err = cma.Uploads.Delete(spaceID, upload)
+	assert.Nil(err)
+}
+
+func TestAssetFromUpload(t *testing.T) {
+	assert := assert.New(t)
+
+	upload := &Upload{Sys: &Sys{ID: "upload-1"}}
+	asset := &Asset{Sys: &Sys{}}
+	asset.SetTitle("en-US", "Nyan video")
+	asset.SetFile("en-US", NewUploadFile(upload, "nyan.mp4", "video/mp4"))
+
+	data, err := json.Marshal(asset)
+	assert.Nil(err)
+
+	var payload map[string]interface{}
+	err = json.Unmarshal(data, &payload)
+	assert.Nil(err)
+
+	file := payload["fields"].(map[string]interface{})["file"].(map[string]interface{})["en-US"].(map[string]interface{})
+	assert.Equal("nyan.mp4", file["fileName"])
+	assert.Equal("video/mp4", file["contentType"])
+
+	sys := file["uploadFrom"].(map[string]interface{})["sys"].(map[string]interface{})
+	assert.Equal("Link", sys["type"])
+	assert.Equal("Upload", sys["linkType"])
+	assert.Equal("upload-1", sys["id"])
+}